@@ -123,6 +123,37 @@ func (c *Context) EllipticalArc(m Method, center geom.Point, radiusX, radiusY, f
 	})
 }
 
+// FillTriangles fills triangles to dst directly, where each triple of
+// indices selects the vertices of one triangle from pts.
+// It is intended for use with the output of geom.Triangulate.
+// It uses the current context, but does not modify the current path.
+func (c *Context) FillTriangles(pts []geom.Point, indices []int) {
+	cs := c.opts.ColorScale
+	vs := make([]ebiten.Vertex, len(pts))
+	for i, pt := range pts {
+		pt = c.TransformPoint(pt)
+		vs[i] = ebiten.Vertex{
+			DstX:   float32(pt.X),
+			DstY:   float32(pt.Y),
+			SrcX:   1,
+			SrcY:   1,
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+	is := make([]uint32, len(indices))
+	for i, idx := range indices {
+		is[i] = uint32(idx)
+	}
+	c.dst.DrawTriangles32(vs, is, whiteSubImage, &ebiten.DrawTrianglesOptions{
+		ColorScaleMode: ebiten.ColorScaleModePremultipliedAlpha,
+		Blend:          c.opts.Blend,
+		AntiAlias:      c.opts.AntiAlias,
+	})
+}
+
 // Context stack functions.
 
 // WithEmpty temporarily swaps out the context's path with a new empty path, for the duration of f.
@@ -241,6 +272,20 @@ func (c *Context) SetLineJoin(j LineJoin) {
 	c.strokeOpts.MiterLimit = float32(j.param)
 }
 
+// StrokeOptions returns the current stroke style, for computing the
+// outline of a stroke with geom.Stroke.
+//
+// The width is in pixels of the destination image, since strokes are
+// drawn after the path is transformed.
+func (c *Context) StrokeOptions() geom.StrokeOptions {
+	return geom.StrokeOptions{
+		Width:      float64(c.strokeOpts.Width),
+		LineCap:    geom.LineCap(c.strokeOpts.LineCap),
+		LineJoin:   geom.LineJoin(c.strokeOpts.LineJoin),
+		MiterLimit: float64(c.strokeOpts.MiterLimit),
+	}
+}
+
 // Set Ebiten drawing controls.
 
 // SetFillRule sets the fill rule for drawing. The default is FillRuleFillAll.
@@ -303,6 +348,16 @@ func (c *Context) TransformPoint(pt geom.Point) geom.Point {
 	return geom.Pt(x, y)
 }
 
+// Transform returns the current transformation matrix.
+func (c *Context) Transform() geom.Transform {
+	return FromGeoM(c.matrix)
+}
+
+// SetTransform replaces the current transformation matrix with t.
+func (c *Context) SetTransform(t geom.Transform) {
+	c.matrix = ToGeoM(t)
+}
+
 // Identity resets the current transformation matrix to the identity matrix.
 // This results in no translating, scaling, rotating, or shearing.
 func (c *Context) Identity() {
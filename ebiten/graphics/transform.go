@@ -0,0 +1,28 @@
+package graphics
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/mknyszek/2d/geom"
+)
+
+// ToGeoM converts t into an equivalent ebiten.GeoM.
+func ToGeoM(t geom.Transform) ebiten.GeoM {
+	a, b, c, d, tx, ty := t.Elements()
+	var g ebiten.GeoM
+	g.SetElement(0, 0, a)
+	g.SetElement(0, 1, b)
+	g.SetElement(0, 2, tx)
+	g.SetElement(1, 0, c)
+	g.SetElement(1, 1, d)
+	g.SetElement(1, 2, ty)
+	return g
+}
+
+// FromGeoM converts g into an equivalent geom.Transform.
+func FromGeoM(g ebiten.GeoM) geom.Transform {
+	return geom.Affine(
+		g.Element(0, 0), g.Element(0, 1),
+		g.Element(1, 0), g.Element(1, 1),
+		g.Element(0, 2), g.Element(1, 2),
+	)
+}
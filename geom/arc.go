@@ -0,0 +1,154 @@
+package geom
+
+import (
+	"iter"
+	"math"
+)
+
+// EllipticalArc represents an arc of an ellipse.
+//
+// The ellipse is centered at Center, has radii RadiusX and RadiusY along
+// its own axes, and is rotated by Rotation radians. The arc begins at
+// the angle Start on the ellipse and travels Sweep radians, which may be
+// negative to travel in the direction of decreasing angle.
+//
+// EllipticalArc implements Curve.
+type EllipticalArc struct {
+	Center           Point
+	RadiusX, RadiusY float64
+	Rotation         float64
+	Start, Sweep     float64
+}
+
+// ArcFromEndpoints converts an elliptical arc from the endpoint
+// parameterization used by SVG into an EllipticalArc.
+//
+// As in SVG, if the radii are too small for the arc to reach from p0 to
+// p1, they are scaled up uniformly until they are just large enough.
+// The result is unspecified if p0 and p1 are identical, or if either
+// radius is zero.
+func ArcFromEndpoints(p0 Point, a ArcParams, p1 Point) EllipticalArc {
+	// See https://www.w3.org/TR/SVG11/implnote.html#ArcConversionEndpointToCenter.
+	rx, ry := math.Abs(a.RadiusX), math.Abs(a.RadiusY)
+	cos, sin := math.Cos(a.Rotation), math.Sin(a.Rotation)
+	dx, dy := (p0.X-p1.X)/2, (p0.Y-p1.Y)/2
+	x1 := cos*dx + sin*dy
+	y1 := -sin*dx + cos*dy
+
+	// Scale the radii up if they're too small to reach.
+	if l := x1*x1/(rx*rx) + y1*y1/(ry*ry); l > 1 {
+		l = math.Sqrt(l)
+		rx, ry = rx*l, ry*l
+	}
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	k := math.Sqrt(max(num, 0) / den)
+	if a.Large == a.Sweep {
+		k = -k
+	}
+	cx1 := k * rx * y1 / ry
+	cy1 := -k * ry * x1 / rx
+	center := Pt(
+		cos*cx1-sin*cy1+(p0.X+p1.X)/2,
+		sin*cx1+cos*cy1+(p0.Y+p1.Y)/2,
+	)
+	u := Vector{(x1 - cx1) / rx, (y1 - cy1) / ry}
+	v := Vector{(-x1 - cx1) / rx, (-y1 - cy1) / ry}
+	start := math.Atan2(u.Y, u.X)
+	sweep := math.Atan2(crossMag(u, v), u.Dot(v))
+	if a.Sweep && sweep < 0 {
+		sweep += 2 * math.Pi
+	} else if !a.Sweep && sweep > 0 {
+		sweep -= 2 * math.Pi
+	}
+	return EllipticalArc{center, rx, ry, a.Rotation, start, sweep}
+}
+
+// Endpoints converts the arc into the endpoint parameterization used by SVG.
+func (e EllipticalArc) Endpoints() (p0 Point, a ArcParams, p1 Point) {
+	a = ArcParams{
+		RadiusX:  e.RadiusX,
+		RadiusY:  e.RadiusY,
+		Rotation: e.Rotation,
+		Large:    math.Abs(e.Sweep) > math.Pi,
+		Sweep:    e.Sweep > 0,
+	}
+	return e.At(0), a, e.At(1)
+}
+
+// at returns the point on the ellipse at angle theta.
+func (e EllipticalArc) at(theta float64) Point {
+	v := Vector{e.RadiusX * math.Cos(theta), e.RadiusY * math.Sin(theta)}
+	return e.Center.Add(v.Rotate(e.Rotation))
+}
+
+// At implements Curve.
+func (e EllipticalArc) At(t float64) Point {
+	return e.at(e.Start + t*e.Sweep)
+}
+
+// Derivative returns the derivative of the arc with respect to t.
+func (e EllipticalArc) Derivative(t float64) Vector {
+	theta := e.Start + t*e.Sweep
+	v := Vector{-e.RadiusX * math.Sin(theta), e.RadiusY * math.Cos(theta)}
+	return v.Rotate(e.Rotation).Scale(e.Sweep)
+}
+
+// Bounds returns the smallest AABB containing the arc.
+func (e EllipticalArc) Bounds() AABB {
+	b := AABB{e.At(0), e.At(0)}.extend(e.At(1))
+	cos, sin := math.Cos(e.Rotation), math.Sin(e.Rotation)
+	tx := math.Atan2(-e.RadiusY*sin, e.RadiusX*cos)
+	ty := math.Atan2(e.RadiusY*cos, e.RadiusX*sin)
+	for _, theta := range [4]float64{tx, tx + math.Pi, ty, ty + math.Pi} {
+		if e.contains(theta) {
+			b = b.extend(e.at(theta))
+		}
+	}
+	return b
+}
+
+// contains returns true if the arc passes through the angle theta.
+func (e EllipticalArc) contains(theta float64) bool {
+	d := theta - e.Start
+	if e.Sweep < 0 {
+		d = -d
+	}
+	d = math.Mod(d, 2*math.Pi)
+	if d < 0 {
+		d += 2 * math.Pi
+	}
+	return d <= math.Abs(e.Sweep)
+}
+
+// Cubics returns an iterator over cubic Bézier curves that together
+// approximate the arc, such that no point on the approximation is further
+// than tolerance from the arc.
+func (e EllipticalArc) Cubics(tolerance float64) iter.Seq[CubicBezier] {
+	return func(yield func(CubicBezier) bool) {
+		// Each cubic approximates a circular arc of angle a, which is then
+		// stretched into the ellipse. The radial error of that approximation
+		// on the unit circle is (4/27) * sin^6(a/4) / cos^2(a/4).
+		r := max(e.RadiusX, e.RadiusY)
+		n := max(int(math.Ceil(math.Abs(e.Sweep)/(math.Pi/2))), 1)
+		for ; n < 1024; n *= 2 {
+			a := math.Abs(e.Sweep) / float64(n)
+			s, c := math.Sin(a/4), math.Cos(a/4)
+			if r*4/27*math.Pow(s, 6)/(c*c) <= tolerance {
+				break
+			}
+		}
+		a := e.Sweep / float64(n)
+		k := 4.0 / 3.0 * math.Tan(a/4)
+		for i := range n {
+			t0 := e.Start + float64(i)*a
+			t1 := t0 + a
+			p0, p3 := e.at(t0), e.at(t1)
+			d0 := Vector{-e.RadiusX * math.Sin(t0), e.RadiusY * math.Cos(t0)}.Rotate(e.Rotation)
+			d1 := Vector{-e.RadiusX * math.Sin(t1), e.RadiusY * math.Cos(t1)}.Rotate(e.Rotation)
+			if !yield(Bezier3(p0, p0.Add(d0.Scale(k)), p3.Add(d1.Scale(-k)), p3)) {
+				return
+			}
+		}
+	}
+}
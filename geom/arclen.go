@@ -0,0 +1,130 @@
+package geom
+
+import (
+	"iter"
+	"math"
+	"sort"
+)
+
+// ArcLength returns the length of the curve.
+func ArcLength(c Curve) float64 {
+	return arcLength(c, 0, 1, gaussLegendre(c, 0, 1), 0)
+}
+
+// arcLength adaptively integrates the speed of c from t0 to t1,
+// given an estimate of the result.
+func arcLength(c Curve, t0, t1, est float64, depth int) float64 {
+	mid := (t0 + t1) / 2
+	l0 := gaussLegendre(c, t0, mid)
+	l1 := gaussLegendre(c, mid, t1)
+	if depth >= 16 || math.Abs(l0+l1-est) <= 1e-10*max(est, 1) {
+		return l0 + l1
+	}
+	return arcLength(c, t0, mid, l0, depth+1) + arcLength(c, mid, t1, l1, depth+1)
+}
+
+// gaussLegendre integrates the speed of c from t0 to t1 using
+// 5-point Gauss-Legendre quadrature.
+func gaussLegendre(c Curve, t0, t1 float64) float64 {
+	nodes := [5]struct{ x, w float64 }{
+		{0, 0.5688888888888889},
+		{-0.5384693101056831, 0.4786286704993665},
+		{0.5384693101056831, 0.4786286704993665},
+		{-0.9061798459386640, 0.2369268850561891},
+		{0.9061798459386640, 0.2369268850561891},
+	}
+	h := (t1 - t0) / 2
+	m := (t0 + t1) / 2
+	var sum float64
+	for _, n := range nodes {
+		sum += n.w * derivative(c, m+h*n.x).Length()
+	}
+	return sum * h
+}
+
+// ArcLengthCurve is a Curve reparameterized by arc length, so that its
+// parameter is proportional to the distance traveled along the curve.
+// Objects following an ArcLengthCurve at a constant rate of t move at
+// a constant speed.
+//
+// ArcLengthCurve implements Curve, and may be used as a
+// [github.com/mknyszek/2d/tween.Function].
+type ArcLengthCurve struct {
+	c Curve
+
+	// ts and ss are a table mapping parameters of c
+	// to the distance along c at that parameter.
+	ts, ss []float64
+}
+
+// ByArcLength reparameterizes c by arc length.
+func ByArcLength(c Curve) ArcLengthCurve {
+	const n = 256
+	a := ArcLengthCurve{c: c, ts: make([]float64, n+1), ss: make([]float64, n+1)}
+	for i := 1; i <= n; i++ {
+		a.ts[i] = float64(i) / n
+		a.ss[i] = a.ss[i-1] + gaussLegendre(c, a.ts[i-1], a.ts[i])
+	}
+	return a
+}
+
+// Length returns the length of the curve.
+func (a ArcLengthCurve) Length() float64 {
+	return a.ss[len(a.ss)-1]
+}
+
+// Param returns the parameter of the underlying curve at distance s along it.
+func (a ArcLengthCurve) Param(s float64) float64 {
+	if s <= 0 {
+		return 0
+	}
+	if s >= a.Length() {
+		return 1
+	}
+	i := sort.SearchFloat64s(a.ss, s) - 1
+	t0, t1 := a.ts[i], a.ts[i+1]
+	s0, s1 := a.ss[i], a.ss[i+1]
+	t := t0 + (t1-t0)*(s-s0)/(s1-s0)
+
+	// Refine the linear estimate with a couple of Newton steps.
+	for range 2 {
+		v := derivative(a.c, t).Length()
+		if v == 0 {
+			break
+		}
+		t = min(max(t-(s0+gaussLegendre(a.c, t0, t)-s)/v, t0), t1)
+	}
+	return t
+}
+
+// At implements Curve.
+func (a ArcLengthCurve) At(t float64) Point {
+	l := a.Length()
+	if l == 0 {
+		return a.c.At(t)
+	}
+	return a.c.At(a.Param(t * l))
+}
+
+// Spaced returns an iterator over points along the curve spaced evenly
+// by distance d, starting at the beginning of the curve.
+//
+// The end of the curve is only produced if the curve's length is a
+// multiple of d.
+func (a ArcLengthCurve) Spaced(d float64) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		if d <= 0 {
+			return
+		}
+		l := a.Length()
+		for i := 0; ; i++ {
+			s := float64(i) * d
+			if s > l {
+				return
+			}
+			if !yield(a.c.At(a.Param(s))) {
+				return
+			}
+		}
+	}
+}
@@ -23,6 +23,9 @@ func (qb QuadraticBezier) At(t float64) Point {
 
 // Approx returns an iterator that can be used to approximate the curve
 // by drawing straight lines between adjacent points.
+//
+// To control the accuracy of the approximation, use
+// [QuadraticBezier.Flatten] instead.
 func (qb QuadraticBezier) Approx() iter.Seq[Point] {
 	return func(yield func(Point) bool) {
 		l := (math.Hypot(qb.b.X-qb.a.X, qb.b.Y-qb.a.Y) +
@@ -68,6 +71,9 @@ func (cb CubicBezier) At(t float64) Point {
 
 // Approx returns an iterator that can be used to approximate the curve
 // by drawing straight lines between adjacent points.
+//
+// To control the accuracy of the approximation, use
+// [CubicBezier.Flatten] instead.
 func (cb CubicBezier) Approx() iter.Seq[Point] {
 	return func(yield func(Point) bool) {
 		l := (math.Hypot(cb.b.X-cb.a.X, cb.b.Y-cb.a.Y) +
@@ -96,3 +102,213 @@ func cubic(x0, y0, x1, y1, x2, y2, x3, y3, t float64) (x, y float64) {
 	y = a*y0 + b*y1 + c*y2 + d*y3
 	return
 }
+
+// Start returns the first point of the curve.
+func (qb QuadraticBezier) Start() Point {
+	return qb.a
+}
+
+// Control returns the control point of the curve.
+func (qb QuadraticBezier) Control() Point {
+	return qb.b
+}
+
+// End returns the last point of the curve.
+func (qb QuadraticBezier) End() Point {
+	return qb.c
+}
+
+// Derivative returns the derivative of the curve with respect to t.
+func (qb QuadraticBezier) Derivative(t float64) Vector {
+	return Vec(qb.a, qb.b).Scale(2 * (1 - t)).Add(Vec(qb.b, qb.c).Scale(2 * t))
+}
+
+// Tangent returns the unit tangent vector of the curve at t.
+func (qb QuadraticBezier) Tangent(t float64) Vector {
+	return tangent(qb, qb.Derivative(t), t)
+}
+
+// Normal returns the unit normal vector of the curve at t, pointing
+// to the left of the direction of travel.
+func (qb QuadraticBezier) Normal(t float64) Vector {
+	return leftNormal(qb.Tangent(t))
+}
+
+// SplitAt splits the curve at t into two curves that together trace
+// the same path.
+func (qb QuadraticBezier) SplitAt(t float64) (QuadraticBezier, QuadraticBezier) {
+	ab := Seg(qb.a, qb.b).At(t)
+	bc := Seg(qb.b, qb.c).At(t)
+	m := Seg(ab, bc).At(t)
+	return QuadraticBezier{qb.a, ab, m}, QuadraticBezier{m, bc, qb.c}
+}
+
+// Sub returns the part of the curve between t0 and t1 as a new curve.
+//
+// If t1 is less than t0, the new curve runs in the opposite direction.
+func (qb QuadraticBezier) Sub(t0, t1 float64) QuadraticBezier {
+	if t1 < t0 {
+		r := qb.Sub(t1, t0)
+		return QuadraticBezier{r.c, r.b, r.a}
+	}
+	_, qb = qb.SplitAt(t0)
+	if t0 < 1 {
+		qb, _ = qb.SplitAt((t1 - t0) / (1 - t0))
+	}
+	return qb
+}
+
+// Bounds returns the smallest AABB containing the curve.
+func (qb QuadraticBezier) Bounds() AABB {
+	b := AABB{qb.a, qb.a}.extend(qb.c)
+	for _, t := range [2]float64{
+		quadraticExtremum(qb.a.X, qb.b.X, qb.c.X),
+		quadraticExtremum(qb.a.Y, qb.b.Y, qb.c.Y),
+	} {
+		if t > 0 && t < 1 {
+			b = b.extend(qb.At(t))
+		}
+	}
+	return b
+}
+
+// quadraticExtremum returns the parameter of the extremum of a 1D quadratic
+// Bézier curve, or -1 if there isn't one.
+func quadraticExtremum(a, b, c float64) float64 {
+	d := a - 2*b + c
+	if d == 0 {
+		return -1
+	}
+	return (a - b) / d
+}
+
+// Elevate returns the curve as an equivalent cubic Bézier curve.
+func (qb QuadraticBezier) Elevate() CubicBezier {
+	return CubicBezier{
+		qb.a,
+		qb.a.Add(Vec(qb.a, qb.b).Scale(2.0 / 3.0)),
+		qb.c.Add(Vec(qb.c, qb.b).Scale(2.0 / 3.0)),
+		qb.c,
+	}
+}
+
+// Start returns the first point of the curve.
+func (cb CubicBezier) Start() Point {
+	return cb.a
+}
+
+// Control0 returns the first control point of the curve.
+func (cb CubicBezier) Control0() Point {
+	return cb.b
+}
+
+// Control1 returns the second control point of the curve.
+func (cb CubicBezier) Control1() Point {
+	return cb.c
+}
+
+// End returns the last point of the curve.
+func (cb CubicBezier) End() Point {
+	return cb.d
+}
+
+// Derivative returns the derivative of the curve with respect to t.
+func (cb CubicBezier) Derivative(t float64) Vector {
+	u := 1 - t
+	return Vec(cb.a, cb.b).Scale(3 * u * u).
+		Add(Vec(cb.b, cb.c).Scale(6 * u * t)).
+		Add(Vec(cb.c, cb.d).Scale(3 * t * t))
+}
+
+// Tangent returns the unit tangent vector of the curve at t.
+func (cb CubicBezier) Tangent(t float64) Vector {
+	return tangent(cb, cb.Derivative(t), t)
+}
+
+// Normal returns the unit normal vector of the curve at t, pointing
+// to the left of the direction of travel.
+func (cb CubicBezier) Normal(t float64) Vector {
+	return leftNormal(cb.Tangent(t))
+}
+
+// SplitAt splits the curve at t into two curves that together trace
+// the same path.
+func (cb CubicBezier) SplitAt(t float64) (CubicBezier, CubicBezier) {
+	ab := Seg(cb.a, cb.b).At(t)
+	bc := Seg(cb.b, cb.c).At(t)
+	cd := Seg(cb.c, cb.d).At(t)
+	abc := Seg(ab, bc).At(t)
+	bcd := Seg(bc, cd).At(t)
+	m := Seg(abc, bcd).At(t)
+	return CubicBezier{cb.a, ab, abc, m}, CubicBezier{m, bcd, cd, cb.d}
+}
+
+// Sub returns the part of the curve between t0 and t1 as a new curve.
+//
+// If t1 is less than t0, the new curve runs in the opposite direction.
+func (cb CubicBezier) Sub(t0, t1 float64) CubicBezier {
+	if t1 < t0 {
+		r := cb.Sub(t1, t0)
+		return CubicBezier{r.d, r.c, r.b, r.a}
+	}
+	_, cb = cb.SplitAt(t0)
+	if t0 < 1 {
+		cb, _ = cb.SplitAt((t1 - t0) / (1 - t0))
+	}
+	return cb
+}
+
+// Bounds returns the smallest AABB containing the curve.
+func (cb CubicBezier) Bounds() AABB {
+	b := AABB{cb.a, cb.a}.extend(cb.d)
+	ts := append(
+		cubicExtrema(cb.a.X, cb.b.X, cb.c.X, cb.d.X),
+		cubicExtrema(cb.a.Y, cb.b.Y, cb.c.Y, cb.d.Y)...,
+	)
+	for _, t := range ts {
+		if t > 0 && t < 1 {
+			b = b.extend(cb.At(t))
+		}
+	}
+	return b
+}
+
+// cubicExtrema returns the parameters of the extrema of a 1D cubic
+// Bézier curve.
+func cubicExtrema(a, b, c, d float64) []float64 {
+	// The derivative is a quadratic At^2 + Bt + C.
+	qa := 3 * (-a + 3*b - 3*c + d)
+	qb := 6 * (a - 2*b + c)
+	qc := 3 * (b - a)
+	if qa == 0 {
+		if qb == 0 {
+			return nil
+		}
+		return []float64{-qc / qb}
+	}
+	disc := qb*qb - 4*qa*qc
+	if disc < 0 {
+		return nil
+	}
+	sq := math.Sqrt(disc)
+	return []float64{(-qb + sq) / (2 * qa), (-qb - sq) / (2 * qa)}
+}
+
+// tangent normalizes the derivative d of c at t. If the derivative
+// vanishes, as it does when a control point coincides with an end
+// point, the direction of the curve in the neighborhood of t is used.
+func tangent(c Curve, d Vector, t float64) Vector {
+	if d == Zero {
+		const h = 1e-6
+		d = Vec(c.At(max(t-h, 0)), c.At(min(t+h, 1)))
+		if d == Zero {
+			return Zero
+		}
+	}
+	return d.Normalize()
+}
+
+// leftNormal rotates v a quarter turn counter-clockwise.
+func leftNormal(v Vector) Vector {
+	return Vector{-v.Y, v.X}
+}
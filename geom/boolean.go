@@ -0,0 +1,324 @@
+package geom
+
+import (
+	"math"
+	"slices"
+)
+
+// BoolOp is a boolean operation that combines two regions. See [Combine].
+type BoolOp int
+
+const (
+	// Union covers everything in either region.
+	Union BoolOp = iota
+
+	// Intersection covers everything in both regions.
+	Intersection
+
+	// Difference covers everything in the first region but not the second.
+	Difference
+
+	// Xor covers everything in exactly one of the two regions.
+	Xor
+)
+
+func (op BoolOp) apply(inA, inB bool) bool {
+	switch op {
+	case Intersection:
+		return inA && inB
+	case Difference:
+		return inA && !inB
+	case Xor:
+		return inA != inB
+	}
+	return inA || inB
+}
+
+// Combine combines the regions described by a and b with op, and returns
+// the boundary of the result.
+//
+// Each region is the set of points inside its polygons according to rule,
+// where the winding numbers of all the polygons in the region are summed.
+// A region may therefore have several separate parts, and holes: under
+// [NonZero], holes must wind in the opposite direction to the polygons
+// that contain them, while under [EvenOdd] any direction will do.
+//
+// In the result, outer boundaries are counter-clockwise and holes are
+// clockwise, and no two polygons cross one another, though they may touch
+// at a vertex. It describes the same region under either fill rule.
+// See [Winding].
+func Combine(op BoolOp, a, b []Polygon, rule FillRule) []Polygon {
+	var bounds AABB
+	first := true
+	for _, set := range [2][]Polygon{a, b} {
+		for _, p := range set {
+			if len(p) == 0 {
+				continue
+			}
+			if first {
+				bounds, first = p.Bounds(), false
+			}
+			bounds = bounds.Union(p.Bounds())
+		}
+	}
+	sn := newSnapper(1e-9 * max(bounds.Dx(), bounds.Dy(), 1))
+
+	// Gather every edge, then split them wherever they meet, so that
+	// edges only ever touch at their end points.
+	type edge struct {
+		s   Segment
+		set int
+	}
+	var edges []edge
+	for set, polys := range [2][]Polygon{a, b} {
+		for _, p := range polys {
+			for e := range p.Edges() {
+				if s := Seg(sn.snap(e.Start), sn.snap(e.End)); !s.ZeroLength() {
+					edges = append(edges, edge{s, set})
+				}
+			}
+		}
+	}
+	splits := make([][]Point, len(edges))
+	for i := range edges {
+		bi := edges[i].s.Bounds()
+		for j := i + 1; j < len(edges); j++ {
+			if !bi.overlaps(edges[j].s.Bounds(), sn.eps) {
+				continue
+			}
+			x, ok := edges[i].s.Intersection(edges[j].s)
+			if !ok {
+				continue
+			}
+			for _, p := range [2]Point{x.Start, x.End} {
+				p = sn.snap(p)
+				splits[i] = append(splits[i], p)
+				splits[j] = append(splits[j], p)
+			}
+		}
+	}
+
+	// Merge coincident pieces, tracking how many times each region's
+	// boundary runs along them in each direction.
+	type piece struct {
+		s     Segment // in canonical direction
+		delta [2]int  // net count in canonical direction, per region
+	}
+	var pieces []piece
+	index := make(map[Segment]int)
+	var directed [2][]Segment
+	for i, e := range edges {
+		pts := append(splits[i], e.s.Start, e.s.End)
+		d := Vec(e.s.Start, e.s.End)
+		slices.SortFunc(pts, func(p, q Point) int {
+			return cmpFloat(Vec(e.s.Start, p).Dot(d), Vec(e.s.Start, q).Dot(d))
+		})
+		pts = slices.Compact(pts)
+		for k := 1; k < len(pts); k++ {
+			s := Seg(pts[k-1], pts[k])
+			directed[e.set] = append(directed[e.set], s)
+			c, sign := canonical(s)
+			j, ok := index[c]
+			if !ok {
+				j = len(pieces)
+				index[c] = j
+				pieces = append(pieces, piece{s: c})
+			}
+			pieces[j].delta[e.set] += sign
+		}
+	}
+
+	// Keep the pieces that separate the result from everything else,
+	// directed so that the result is on their left.
+	var out []Segment
+	for _, pc := range pieces {
+		var left, right [2]bool
+		for set := range 2 {
+			if pc.delta[set] == 0 {
+				l := rule.inside(sideWinding(directed[set], pc.s))
+				left[set], right[set] = l, l
+				continue
+			}
+			l, r := sideWindings(directed[set], pc.s, pc.delta[set])
+			left[set], right[set] = rule.inside(l), rule.inside(r)
+		}
+		inL, inR := op.apply(left[0], left[1]), op.apply(right[0], right[1])
+		switch {
+		case inL && !inR:
+			out = append(out, pc.s)
+		case inR && !inL:
+			out = append(out, Seg(pc.s.End, pc.s.Start))
+		}
+	}
+	return linkContours(out, sn.eps)
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// canonical returns s directed upward, or rightward if it's horizontal,
+// along with 1 if that's the direction s already had or -1 otherwise.
+func canonical(s Segment) (Segment, int) {
+	if s.End.Y > s.Start.Y || (s.End.Y == s.Start.Y && s.End.X > s.Start.X) {
+		return s, 1
+	}
+	return Seg(s.End, s.Start), -1
+}
+
+// sideWindings returns the winding numbers of the segments immediately to
+// the left and right of s, which is in canonical direction, and which the
+// segments run along delta times in that direction.
+func sideWindings(segs []Segment, s Segment, delta int) (left, right int) {
+	if s.Start.Y == s.End.Y {
+		left = sideWinding(segs, s)
+		return left, left - delta
+	}
+	right = sideWinding(segs, s)
+	return right + delta, right
+}
+
+// sideWinding returns the winding number of the segments at the midpoint
+// of s, ignoring any segments that lie along s. This is the winding number
+// just to the right of s if it points upward, and just above s if it's
+// horizontal.
+func sideWinding(segs []Segment, s Segment) int {
+	m := s.At(0.5)
+	// Cast the ray upward for horizontal segments, by rotating everything
+	// a quarter turn clockwise.
+	rot := func(p Point) Point { return p }
+	if s.Start.Y == s.End.Y {
+		rot = func(p Point) Point { return Pt(p.Y, -p.X) }
+	}
+	m = rot(m)
+	w := 0
+	for _, e := range segs {
+		if e == s || (e.Start == s.End && e.End == s.Start) {
+			continue
+		}
+		a, b := rot(e.Start), rot(e.End)
+		if a.Y <= m.Y {
+			if b.Y > m.Y && orient(a, b, m) > 0 {
+				w++
+			}
+		} else if b.Y <= m.Y && orient(a, b, m) < 0 {
+			w--
+		}
+	}
+	return w
+}
+
+func (r FillRule) inside(w int) bool {
+	if r == EvenOdd {
+		return w%2 != 0
+	}
+	return w != 0
+}
+
+// linkContours joins directed segments into closed polygons.
+func linkContours(segs []Segment, eps float64) []Polygon {
+	outgoing := make(map[Point][]int)
+	for i, s := range segs {
+		outgoing[s.Start] = append(outgoing[s.Start], i)
+	}
+	used := make([]bool, len(segs))
+	var polys []Polygon
+	for i := range segs {
+		if used[i] {
+			continue
+		}
+		var poly Polygon
+		cur := i
+		used[cur] = true
+		for {
+			poly = append(poly, segs[cur].Start)
+			v := segs[cur].End
+			if v == segs[i].Start {
+				break
+			}
+			// Take the sharpest left turn, which keeps contours that
+			// touch at a vertex separate.
+			back := Vec(v, segs[cur].Start)
+			next, best := -1, math.Inf(1)
+			for _, j := range outgoing[v] {
+				if used[j] {
+					continue
+				}
+				o := Vec(segs[j].Start, segs[j].End)
+				a := math.Atan2(crossMag(o, back), o.Dot(back))
+				if a <= 0 {
+					a += 2 * math.Pi
+				}
+				if a < best {
+					next, best = j, a
+				}
+			}
+			if next < 0 {
+				poly = nil
+				break
+			}
+			cur = next
+			used[cur] = true
+		}
+		if poly = simplifyCollinear(poly, eps); len(poly) >= 3 {
+			polys = append(polys, poly)
+		}
+	}
+	return polys
+}
+
+// simplifyCollinear removes vertices that lie within eps of the line
+// between their neighbors.
+func simplifyCollinear(p Polygon, eps float64) Polygon {
+	for changed := true; changed && len(p) >= 3; {
+		changed = false
+		for i := 0; i < len(p) && len(p) >= 3; i++ {
+			a, b, c := p[(i+len(p)-1)%len(p)], p[i], p[(i+1)%len(p)]
+			if Seg(a, c).Distance(b) <= eps {
+				p = slices.Delete(p, i, i+1)
+				changed = true
+				i--
+			}
+		}
+	}
+	return p
+}
+
+// overlaps returns true if a and b overlap or lie within eps of each other.
+func (a AABB) overlaps(b AABB, eps float64) bool {
+	return a.Min.X <= b.Max.X+eps && b.Min.X <= a.Max.X+eps &&
+		a.Min.Y <= b.Max.Y+eps && b.Min.Y <= a.Max.Y+eps
+}
+
+// snapper merges points that lie within eps of each other, so that
+// nearly coincident vertices become exactly coincident.
+type snapper struct {
+	eps   float64
+	cells map[[2]int64][]Point
+}
+
+func newSnapper(eps float64) *snapper {
+	return &snapper{eps: eps, cells: make(map[[2]int64][]Point)}
+}
+
+func (sn *snapper) snap(p Point) Point {
+	cx, cy := int64(math.Floor(p.X/sn.eps)), int64(math.Floor(p.Y/sn.eps))
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, q := range sn.cells[[2]int64{cx + dx, cy + dy}] {
+				if math.Abs(q.X-p.X) <= sn.eps && math.Abs(q.Y-p.Y) <= sn.eps {
+					return q
+				}
+			}
+		}
+	}
+	c := [2]int64{cx, cy}
+	sn.cells[c] = append(sn.cells[c], p)
+	return p
+}
@@ -0,0 +1,85 @@
+package geom
+
+// Capsule represents the set of points within some radius of a
+// line segment, called the spine.
+//
+// Capsule implements Shape.
+type Capsule struct {
+	Spine  Segment
+	Radius float64
+}
+
+// Caps creates a new Capsule whose spine runs between a and b.
+func Caps(a, b Point, radius float64) Capsule {
+	return Capsule{Seg(a, b), radius}
+}
+
+// Support implements Shape.
+func (c Capsule) Support(d Vector) Point {
+	return Circ(c.Spine.Support(d), c.Radius).Support(d)
+}
+
+func (c Capsule) rounded() (Shape, float64) {
+	return c.Spine, c.Radius
+}
+
+// Contains returns true if p lies within the capsule or on its boundary.
+func (c Capsule) Contains(p Point) bool {
+	q, _ := c.Spine.Closest(p)
+	return Vec(q, p).Length2() <= c.Radius*c.Radius
+}
+
+// Bounds returns the smallest AABB containing the capsule.
+func (c Capsule) Bounds() AABB {
+	a := Circ(c.Spine.Start, c.Radius).Bounds()
+	b := Circ(c.Spine.End, c.Radius).Bounds()
+	return a.Union(b)
+}
+
+// IntersectsCircle returns true if the capsule and circle intersect.
+//
+// Like [AABB.Intersects], shapes that only touch are not considered intersecting.
+func (c Capsule) IntersectsCircle(o Circle) bool {
+	p, _ := c.Spine.Closest(o.Center)
+	return Circ(p, c.Radius).Intersects(o)
+}
+
+// Intersects returns true if the two capsules intersect.
+func (c Capsule) Intersects(o Capsule) bool {
+	return Overlaps(c, o)
+}
+
+// IntersectsAABB returns true if the capsule and AABB intersect.
+func (c Capsule) IntersectsAABB(b AABB) bool {
+	return Overlaps(c, b)
+}
+
+// IntersectsSegment returns true if the segment passes through the capsule.
+func (c Capsule) IntersectsSegment(s Segment) bool {
+	return Overlaps(c, s)
+}
+
+// PenetrationCircle returns a Contact describing the penetration of
+// capsule c into circle o. Returns false if the two do not intersect.
+func (c Capsule) PenetrationCircle(o Circle) (Contact, bool) {
+	p, _ := c.Spine.Closest(o.Center)
+	return Circ(p, c.Radius).Penetration(o)
+}
+
+// Penetration returns a Contact describing the penetration of capsule c
+// into capsule o. Returns false if the two do not intersect.
+func (c Capsule) Penetration(o Capsule) (Contact, bool) {
+	return Penetration(c, o)
+}
+
+// PenetrationAABB returns a Contact describing the penetration of
+// capsule c into the AABB b. Returns false if the two do not intersect.
+func (c Capsule) PenetrationAABB(b AABB) (Contact, bool) {
+	return Penetration(c, b)
+}
+
+// PenetrationSegment returns a Contact describing the penetration of
+// capsule c by the segment s. Returns false if the two do not intersect.
+func (c Capsule) PenetrationSegment(s Segment) (Contact, bool) {
+	return Penetration(c, s)
+}
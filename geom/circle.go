@@ -0,0 +1,113 @@
+package geom
+
+import "math"
+
+// Circle represents a circle in R^2.
+//
+// Circle implements Shape.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Circ creates a new Circle with the provided center and radius.
+func Circ(center Point, radius float64) Circle {
+	return Circle{center, radius}
+}
+
+// Support implements Shape.
+func (c Circle) Support(d Vector) Point {
+	if d == Zero {
+		return c.Center.Add(Vector{c.Radius, 0})
+	}
+	return c.Center.Add(d.Normalize().Scale(c.Radius))
+}
+
+func (c Circle) rounded() (Shape, float64) {
+	return pointShape(c.Center), c.Radius
+}
+
+// Contains returns true if p lies within the circle or on its boundary.
+func (c Circle) Contains(p Point) bool {
+	return Vec(c.Center, p).Length2() <= c.Radius*c.Radius
+}
+
+// Bounds returns the smallest AABB containing the circle.
+func (c Circle) Bounds() AABB {
+	r := Vector{c.Radius, c.Radius}
+	return AABB{c.Center.Add(r.Neg()), c.Center.Add(r)}
+}
+
+// Intersects returns true if the two circles intersect.
+//
+// Like [AABB.Intersects], circles that only touch are not considered intersecting.
+func (c Circle) Intersects(o Circle) bool {
+	r := c.Radius + o.Radius
+	return Vec(c.Center, o.Center).Length2() < r*r
+}
+
+// IntersectsCapsule returns true if the circle and capsule intersect.
+func (c Circle) IntersectsCapsule(o Capsule) bool {
+	return o.IntersectsCircle(c)
+}
+
+// IntersectsAABB returns true if the circle and AABB intersect.
+func (c Circle) IntersectsAABB(b AABB) bool {
+	return Overlaps(c, b)
+}
+
+// IntersectsSegment returns true if the segment passes through the circle.
+func (c Circle) IntersectsSegment(s Segment) bool {
+	p, _ := s.Closest(c.Center)
+	return Vec(c.Center, p).Length2() < c.Radius*c.Radius
+}
+
+// Penetration returns a Contact describing the penetration of circle c
+// into circle o. Returns false if the two do not intersect.
+func (c Circle) Penetration(o Circle) (Contact, bool) {
+	return c.penetrationPoint(o.Center, o.Radius)
+}
+
+// PenetrationCapsule returns a Contact describing the penetration of
+// circle c into capsule o. Returns false if the two do not intersect.
+func (c Circle) PenetrationCapsule(o Capsule) (Contact, bool) {
+	p, _ := o.Spine.Closest(c.Center)
+	return c.penetrationPoint(p, o.Radius)
+}
+
+// PenetrationAABB returns a Contact describing the penetration of
+// circle c into the AABB b. Returns false if the two do not intersect.
+func (c Circle) PenetrationAABB(b AABB) (Contact, bool) {
+	return Penetration(c, b)
+}
+
+// PenetrationSegment returns a Contact describing the penetration of
+// circle c by the segment s. Returns false if the two do not intersect.
+func (c Circle) PenetrationSegment(s Segment) (Contact, bool) {
+	p, _ := s.Closest(c.Center)
+	ct, ok := c.penetrationPoint(p, 0)
+	if ok && ct.Depth == c.Radius {
+		// The center lies on the segment, so push out perpendicular to it.
+		if n := Vec(s.Start, s.End); n != Zero {
+			ct.Normal = n.RightNormal().Normalize()
+		}
+	}
+	return ct, ok
+}
+
+// penetrationPoint computes the penetration of c into the circle of radius r
+// centered at p.
+func (c Circle) penetrationPoint(p Point, r float64) (Contact, bool) {
+	v := Vec(p, c.Center)
+	rr := c.Radius + r
+	d2 := v.Length2()
+	if d2 >= rr*rr {
+		return Contact{}, false
+	}
+	n := Vector{1, 0}
+	d := math.Sqrt(d2)
+	if d > 0 {
+		n = v.Scale(1 / d)
+	}
+	return Contact{Normal: n, Depth: rr - d, Point: p.Add(n.Scale(r))}, true
+}
@@ -0,0 +1,161 @@
+package geom
+
+import "math"
+
+// Closest returns the point on s closest to p, along with the
+// parameter t at which s reaches that point. See [Segment.At].
+func (s Segment) Closest(p Point) (Point, float64) {
+	d := Vec(s.Start, s.End)
+	l2 := d.Length2()
+	if l2 == 0 {
+		return s.Start, 0
+	}
+	t := clamp01(Vec(s.Start, p).Dot(d) / l2)
+	return s.At(t), t
+}
+
+// Distance returns the distance from s to p.
+func (s Segment) Distance(p Point) float64 {
+	q, _ := s.Closest(p)
+	return Vec(p, q).Length()
+}
+
+// ClosestSegment returns the closest pair of points between s0 and s1,
+// along with the parameters at which each segment reaches its point.
+//
+// If the segments intersect, both points are the same.
+func (s0 Segment) ClosestSegment(s1 Segment) (p0 Point, t0 float64, p1 Point, t1 float64) {
+	// See Ericson, Real-Time Collision Detection, section 5.1.9.
+	d0 := Vec(s0.Start, s0.End)
+	d1 := Vec(s1.Start, s1.End)
+	r := Vec(s1.Start, s0.Start)
+	a, e, f := d0.Dot(d0), d1.Dot(d1), d1.Dot(r)
+	switch {
+	case a == 0 && e == 0:
+	case a == 0:
+		t1 = clamp01(f / e)
+	case e == 0:
+		t0 = clamp01(-d0.Dot(r) / a)
+	default:
+		b, c := d0.Dot(d1), d0.Dot(r)
+		if denom := a*e - b*b; denom != 0 {
+			t0 = clamp01((b*f - c*e) / denom)
+		}
+		t1 = (b*t0 + f) / e
+		if t1 < 0 {
+			t1 = 0
+			t0 = clamp01(-c / a)
+		} else if t1 > 1 {
+			t1 = 1
+			t0 = clamp01((b - c) / a)
+		}
+	}
+	return s0.At(t0), t0, s1.At(t1), t1
+}
+
+// DistanceSegment returns the distance between s0 and s1.
+func (s0 Segment) DistanceSegment(s1 Segment) float64 {
+	p0, _, p1, _ := s0.ClosestSegment(s1)
+	return Vec(p0, p1).Length()
+}
+
+// Closest returns the point in a closest to p.
+//
+// If p lies within a, p is returned.
+func (a AABB) Closest(p Point) Point {
+	return Pt(min(max(p.X, a.Min.X), a.Max.X), min(max(p.Y, a.Min.Y), a.Max.Y))
+}
+
+// Distance returns the distance from a to p. If p lies within a,
+// the distance is zero.
+func (a AABB) Distance(p Point) float64 {
+	return Vec(p, a.Closest(p)).Length()
+}
+
+// Closest returns the point on the polygon's boundary closest to pt,
+// the index of the edge it lies on, and the parameter along that edge
+// at which it lies. See [Polygon.Edge].
+//
+// The point is always on the boundary, even if pt lies inside the polygon.
+// Returns -1 for the edge index if the polygon is empty.
+func (p Polygon) Closest(pt Point) (Point, int, float64) {
+	best, bestEdge, bestT := Point{}, -1, 0.0
+	bestD := math.Inf(1)
+	for i := range p {
+		q, t := p.Edge(i).Closest(pt)
+		if d := Vec(pt, q).Length2(); d < bestD {
+			best, bestEdge, bestT, bestD = q, i, t, d
+		}
+	}
+	return best, bestEdge, bestT
+}
+
+// Closest returns the point on the curve closest to p, along with the
+// parameter at which the curve reaches that point.
+func (qb QuadraticBezier) Closest(p Point) (Point, float64) {
+	return ClosestOnCurve(qb, p)
+}
+
+// Closest returns the point on the curve closest to p, along with the
+// parameter at which the curve reaches that point.
+func (cb CubicBezier) Closest(p Point) (Point, float64) {
+	return ClosestOnCurve(cb, p)
+}
+
+// ClosestOnCurve returns the point on c closest to p, along with
+// the parameter at which c reaches that point.
+//
+// The result is found numerically by sampling the curve and then refining
+// the best sample, so it may miss features of the curve that are much
+// smaller than the sampling interval.
+func ClosestOnCurve(c Curve, p Point) (Point, float64) {
+	const samples = 64
+	dist := func(t float64) float64 {
+		return Vec(p, c.At(t)).Length2()
+	}
+	bestT, bestD := 0.0, dist(0)
+	for i := 1; i <= samples; i++ {
+		t := float64(i) / samples
+		if d := dist(t); d < bestD {
+			bestT, bestD = t, d
+		}
+	}
+
+	// Refine by bisecting on the sign of the derivative of the distance
+	// around the best sample. It changes from negative to positive at
+	// a local minimum.
+	slope := func(t float64) float64 {
+		return Vec(p, c.At(t)).Dot(derivative(c, t))
+	}
+	lo := max(bestT-1.0/samples, 0)
+	hi := min(bestT+1.0/samples, 1)
+	if slope(lo) < 0 && slope(hi) > 0 {
+		for range 64 {
+			mid := (lo + hi) / 2
+			if slope(mid) < 0 {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		if t := (lo + hi) / 2; dist(t) <= bestD {
+			bestT = t
+		}
+	}
+	return c.At(bestT), bestT
+}
+
+// derivative returns the derivative of c at t, estimating it numerically
+// if c doesn't provide one.
+func derivative(c Curve, t float64) Vector {
+	if d, ok := c.(interface{ Derivative(float64) Vector }); ok {
+		return d.Derivative(t)
+	}
+	const h = 1e-6
+	t0, t1 := max(t-h, 0), min(t+h, 1)
+	return Vec(c.At(t0), c.At(t1)).Scale(1 / (t1 - t0))
+}
+
+func clamp01(t float64) float64 {
+	return min(max(t, 0), 1)
+}
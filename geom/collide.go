@@ -0,0 +1,86 @@
+package geom
+
+import "math"
+
+// Contact describes how two overlapping shapes penetrate one another.
+type Contact struct {
+	// Normal is the unit vector along which the first shape
+	// must move to separate from the second.
+	Normal Vector
+
+	// Depth is the distance along Normal that the first shape
+	// must move to separate from the second.
+	Depth float64
+
+	// Point is the point of the second shape that penetrates
+	// deepest into the first.
+	Point Point
+}
+
+// MTV returns the minimum translation vector: the smallest translation
+// of the first shape that separates it from the second.
+//
+// Like [AABB.Penetration], this is the vector to move the first
+// shape by in order to resolve the collision.
+func (c Contact) MTV() Vector {
+	return c.Normal.Scale(c.Depth)
+}
+
+// Penetration returns a Contact describing the penetration of convex
+// polygon p into convex polygon q, using the separating axis theorem.
+//
+// Returns false if the two do not intersect. Like [AABB.Intersects],
+// polygons that only touch are not considered intersecting.
+//
+// The result is unspecified if either polygon is not convex.
+func (p Polygon) Penetration(q Polygon) (Contact, bool) {
+	best := Contact{Depth: math.Inf(1)}
+	for _, poly := range [2]Polygon{p, q} {
+		for e := range poly.Edges() {
+			if e.ZeroLength() {
+				continue
+			}
+			axis := Vec(e.Start, e.End).RightNormal().Normalize()
+			pMin, pMax := p.project(axis)
+			qMin, qMax := q.project(axis)
+			back, fwd := pMax-qMin, qMax-pMin
+			if back <= 0 || fwd <= 0 {
+				return Contact{}, false
+			}
+			if back < best.Depth {
+				best = Contact{Normal: axis.Neg(), Depth: back}
+			}
+			if fwd < best.Depth {
+				best = Contact{Normal: axis, Depth: fwd}
+			}
+		}
+	}
+	if math.IsInf(best.Depth, 1) {
+		return Contact{}, false
+	}
+	best.Point = q[0]
+	for _, pt := range q[1:] {
+		if pt.Vector().Dot(best.Normal) > best.Point.Vector().Dot(best.Normal) {
+			best.Point = pt
+		}
+	}
+	return best, true
+}
+
+// PenetrationAABB returns a Contact describing the penetration of convex
+// polygon p into the AABB b. See [Polygon.Penetration].
+func (p Polygon) PenetrationAABB(b AABB) (Contact, bool) {
+	return p.Penetration(b.Polygon())
+}
+
+// project returns the interval covered by the polygon when projected
+// onto the axis.
+func (p Polygon) project(axis Vector) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, pt := range p {
+		d := pt.Vector().Dot(axis)
+		lo = min(lo, d)
+		hi = max(hi, d)
+	}
+	return
+}
@@ -0,0 +1,246 @@
+package geom
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Delaunay is a Delaunay triangulation of a set of points: a triangulation
+// in which no point lies inside the circumcircle of any triangle. Among
+// all triangulations, it avoids long, thin triangles as much as possible.
+type Delaunay struct {
+	// Points are the points that were triangulated.
+	Points []Point
+
+	// Triangles holds three indices into Points for each triangle, in
+	// counter-clockwise order. See [Winding].
+	Triangles []int
+
+	// adj holds, for each edge of each triangle, the triangle on the
+	// other side of it, or -1 if the edge is on the convex hull. Edge k
+	// of triangle t runs from vertex k to vertex k+1.
+	adj []int
+
+	// nbrs holds the points connected to each point by an edge.
+	nbrs [][]int
+}
+
+// NewDelaunay computes the Delaunay triangulation of pts, using the
+// Bowyer-Watson algorithm.
+//
+// Duplicate points are only triangulated once: later copies are left out
+// of every triangle. If all the points are collinear there are no
+// triangles, but adjacent points are still considered neighbors.
+func NewDelaunay(pts []Point) *Delaunay {
+	d := &Delaunay{Points: pts, nbrs: make([][]int, len(pts))}
+
+	// Visit unique points from left to right, so that triangles which
+	// lie entirely to the left of the current point can be set aside.
+	order := make([]int, 0, len(pts))
+	seen := make(map[Point]bool, len(pts))
+	for i, p := range pts {
+		if !seen[p] {
+			seen[p] = true
+			order = append(order, i)
+		}
+	}
+	slices.SortFunc(order, func(i, j int) int {
+		if c := cmp.Compare(pts[i].X, pts[j].X); c != 0 {
+			return c
+		}
+		return cmp.Compare(pts[i].Y, pts[j].Y)
+	})
+	if len(order) < 2 {
+		return d
+	}
+	if d.collinear(order) {
+		for k := 1; k < len(order); k++ {
+			d.link(order[k-1], order[k])
+		}
+		return d
+	}
+
+	// Surround everything in a triangle much larger than the points.
+	work := slices.Clone(pts)
+	b := Polygon(pts).Bounds()
+	c, s := b.Center(), max(b.Dx(), b.Dy(), 1)*100
+	n := len(pts)
+	work = append(work, Pt(c.X-2*s, c.Y-s), Pt(c.X+2*s, c.Y-s), Pt(c.X, c.Y+2*s))
+
+	var open, done []delaunayTri
+	open = append(open, newDelaunayTri(work, n, n+1, n+2))
+	for _, i := range order {
+		p := work[i]
+		var edges [][2]int
+		keep := open[:0]
+		for _, t := range open {
+			switch {
+			case p.X > t.center.X && Vec(t.center, p).X*Vec(t.center, p).X > t.r2:
+				// The point and every later one lie to the right of the
+				// circumcircle, so this triangle is final.
+				done = append(done, t)
+			case t.contains(work, p):
+				edges = append(edges, [2]int{t.v[0], t.v[1]}, [2]int{t.v[1], t.v[2]}, [2]int{t.v[2], t.v[0]})
+			default:
+				keep = append(keep, t)
+			}
+		}
+		open = keep
+
+		// Fill the hole left by the removed triangles with a fan of
+		// triangles around p, one for each edge on the hole's boundary.
+		shared := make(map[[2]int]bool, len(edges))
+		for _, e := range edges {
+			shared[e] = true
+		}
+		for _, e := range edges {
+			if !shared[[2]int{e[1], e[0]}] {
+				open = append(open, newDelaunayTri(work, e[0], e[1], i))
+			}
+		}
+	}
+	for _, t := range append(done, open...) {
+		if t.v[0] < n && t.v[1] < n && t.v[2] < n {
+			d.Triangles = append(d.Triangles, t.v[0], t.v[1], t.v[2])
+		}
+	}
+	d.fillHull()
+	d.connect()
+	return d
+}
+
+// delaunayTri is a triangle under construction in NewDelaunay, with
+// its circumcircle.
+type delaunayTri struct {
+	v      [3]int
+	center Point
+	r2     float64
+}
+
+func newDelaunayTri(pts []Point, a, b, c int) delaunayTri {
+	cc := circumcircle(pts[a], pts[b], pts[c])
+	return delaunayTri{[3]int{a, b, c}, cc.Center, cc.Radius * cc.Radius}
+}
+
+// contains returns true if p lies strictly within t's circumcircle.
+func (t delaunayTri) contains(pts []Point, p Point) bool {
+	return inCircle(pts[t.v[0]], pts[t.v[1]], pts[t.v[2]], p)
+}
+
+// inCircle returns true if d lies strictly within the circumcircle of the
+// counter-clockwise triangle abc.
+func inCircle(a, b, c, d Point) bool {
+	ad, bd, cd := Vec(d, a), Vec(d, b), Vec(d, c)
+	return ad.Length2()*crossMag(bd, cd)+
+		bd.Length2()*crossMag(cd, ad)+
+		cd.Length2()*crossMag(ad, bd) > 0
+}
+
+func (d *Delaunay) collinear(order []int) bool {
+	a, b := d.Points[order[0]], d.Points[order[len(order)-1]]
+	for _, i := range order {
+		if orient(a, b, d.Points[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// fillHull adds triangles to fill any concavities in the triangulation's
+// boundary, which can be left behind when the surrounding triangle is
+// removed.
+func (d *Delaunay) fillHull() {
+	next := make(map[int]int)
+	prev := make(map[int]int)
+	edges := make(map[[2]int]bool)
+	for t := 0; t < len(d.Triangles); t += 3 {
+		for k := range 3 {
+			edges[[2]int{d.Triangles[t+k], d.Triangles[t+(k+1)%3]}] = true
+		}
+	}
+	for e := range edges {
+		if !edges[[2]int{e[1], e[0]}] {
+			next[e[0]], prev[e[1]] = e[1], e[0]
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for b := range next {
+			a, c := prev[b], next[b]
+			if a == c || orient(d.Points[a], d.Points[b], d.Points[c]) >= 0 {
+				continue
+			}
+			tri := Poly(d.Points[a], d.Points[c], d.Points[b])
+			if slices.ContainsFunc(d.Points, func(p Point) bool {
+				return p != tri[0] && p != tri[1] && p != tri[2] && tri.Contains(p, NonZero)
+			}) {
+				continue
+			}
+			d.Triangles = append(d.Triangles, a, c, b)
+			next[a], prev[c] = c, a
+			delete(next, b)
+			delete(prev, b)
+			changed = true
+		}
+	}
+}
+
+// connect computes triangle and point adjacency from the triangles.
+func (d *Delaunay) connect() {
+	d.adj = make([]int, len(d.Triangles))
+	edges := make(map[[2]int]int, len(d.Triangles))
+	for e := range d.Triangles {
+		edges[[2]int{d.Triangles[e], d.Triangles[e-e%3+(e+1)%3]}] = e / 3
+	}
+	for e := range d.Triangles {
+		u, v := d.Triangles[e], d.Triangles[e-e%3+(e+1)%3]
+		t, ok := edges[[2]int{v, u}]
+		if !ok {
+			t = -1
+		}
+		d.adj[e] = t
+		if !ok || u < v {
+			d.link(u, v)
+		}
+	}
+	for i := range d.nbrs {
+		slices.Sort(d.nbrs[i])
+	}
+}
+
+func (d *Delaunay) link(u, v int) {
+	d.nbrs[u] = append(d.nbrs[u], v)
+	d.nbrs[v] = append(d.nbrs[v], u)
+}
+
+// Len returns the number of triangles.
+func (d *Delaunay) Len() int {
+	return len(d.Triangles) / 3
+}
+
+// Triangle returns the indices of the points that make up triangle t,
+// in counter-clockwise order.
+func (d *Delaunay) Triangle(t int) (a, b, c int) {
+	return d.Triangles[3*t], d.Triangles[3*t+1], d.Triangles[3*t+2]
+}
+
+// Adjacent returns the triangles that share an edge with triangle t.
+// Element k is the triangle across the edge from vertex k to the next
+// vertex, or -1 if that edge is on the convex hull.
+func (d *Delaunay) Adjacent(t int) [3]int {
+	return [3]int(d.adj[3*t : 3*t+3])
+}
+
+// Circumcenter returns the center of the circle passing through the
+// corners of triangle t. It's a vertex of the Voronoi diagram.
+func (d *Delaunay) Circumcenter(t int) Point {
+	a, b, c := d.Triangle(t)
+	return circumcircle(d.Points[a], d.Points[b], d.Points[c]).Center
+}
+
+// Neighbors returns an iterator over the points connected to point i
+// by an edge of the triangulation, in ascending order.
+func (d *Delaunay) Neighbors(i int) iter.Seq[int] {
+	return slices.Values(d.nbrs[i])
+}
@@ -21,5 +21,17 @@ A [Curve] is a parametric 2D space curve defined over the closed interval
 [0, 1].
 This package provides a few such curves, such as [Segment] and
 [QuadraticBezier].
+
+Splines such as [CatmullRom], [Hermite], and [BSpline] describe smooth
+curves through or near a list of points, which is often more convenient
+than placing Bézier control points by hand. Each spline can be converted
+into a sequence of [CubicBezier] curves for drawing.
+
+# Shapes
+
+A [Shape] is a convex shape described by its support function.
+[AABB], [OBB], [Segment], [Circle], [Capsule], and convex [Polygon] values
+are all shapes, and any pair of them may be tested for overlap,
+distance, or penetration with [Overlaps], [Distance], and [Penetration].
 */
 package geom
@@ -0,0 +1,104 @@
+package geom
+
+import (
+	"iter"
+	"math"
+)
+
+// Flatten returns an iterator over points that approximate c with
+// straight lines between adjacent points, such that no point on the
+// curve is further than tolerance from the approximation.
+//
+// The tolerance is in the same units as the curve's coordinates.
+// When flattening a curve in world space for display on screen at some
+// scale factor, divide the desired screen-space tolerance by the scale.
+func Flatten(c Curve, tolerance float64) iter.Seq[Point] {
+	if f, ok := c.(interface {
+		Flatten(float64) iter.Seq[Point]
+	}); ok {
+		return f.Flatten(tolerance)
+	}
+	return func(yield func(Point) bool) {
+		// Subdivide uniformly a few times before adapting, so that
+		// small features don't slip between the samples used to
+		// estimate the error.
+		const initial = 8
+		p0 := c.At(0)
+		if !yield(p0) {
+			return
+		}
+		for i := range initial {
+			t0, t1 := float64(i)/initial, float64(i+1)/initial
+			p1 := c.At(t1)
+			if !flatten(c, t0, t1, p0, p1, tolerance, 0, yield) {
+				return
+			}
+			p0 = p1
+		}
+	}
+}
+
+// flatten adaptively flattens c between t0 and t1, whose points are p0
+// and p1. It yields every point after p0, up to and including p1.
+func flatten(c Curve, t0, t1 float64, p0, p1 Point, tolerance float64, depth int, yield func(Point) bool) bool {
+	const maxDepth = 16
+	chord := Seg(p0, p1)
+	mid := (t0 + t1) / 2
+	pm := c.At(mid)
+	if depth < maxDepth && (chord.Distance(pm) > tolerance ||
+		chord.Distance(c.At((t0+mid)/2)) > tolerance ||
+		chord.Distance(c.At((mid+t1)/2)) > tolerance) {
+		return flatten(c, t0, mid, p0, pm, tolerance, depth+1, yield) &&
+			flatten(c, mid, t1, pm, p1, tolerance, depth+1, yield)
+	}
+	return yield(p1)
+}
+
+// Flatten returns an iterator over the segment's two end points.
+// It exists so that a Segment may be flattened like any other Curve.
+func (s Segment) Flatten(tolerance float64) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		_ = yield(s.Start) && yield(s.End)
+	}
+}
+
+// Flatten returns an iterator over points that approximate the curve
+// with straight lines between adjacent points, such that no point on
+// the curve is further than tolerance from the approximation.
+//
+// See [Flatten].
+func (qb QuadraticBezier) Flatten(tolerance float64) iter.Seq[Point] {
+	// Wang's formula bounds the number of uniform segments required.
+	dd := Vec(qb.b, qb.a).Add(Vec(qb.b, qb.c)).Length()
+	return flattenUniform(qb, math.Sqrt(dd/(4*tolerance)))
+}
+
+// Flatten returns an iterator over points that approximate the curve
+// with straight lines between adjacent points, such that no point on
+// the curve is further than tolerance from the approximation.
+//
+// See [Flatten].
+func (cb CubicBezier) Flatten(tolerance float64) iter.Seq[Point] {
+	// Wang's formula bounds the number of uniform segments required.
+	dd := max(
+		Vec(cb.b, cb.a).Add(Vec(cb.b, cb.c)).Length(),
+		Vec(cb.c, cb.b).Add(Vec(cb.c, cb.d)).Length(),
+	)
+	return flattenUniform(cb, math.Sqrt(3*dd/(4*tolerance)))
+}
+
+// flattenUniform returns an iterator over n uniformly spaced points
+// along c, rounding n up to an integer of at least 1.
+func flattenUniform(c Curve, n float64) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		k := 1
+		if !math.IsNaN(n) && n > 1 {
+			k = int(min(math.Ceil(n), 1<<16))
+		}
+		for i := 0; i <= k; i++ {
+			if !yield(c.At(float64(i) / float64(k))) {
+				return
+			}
+		}
+	}
+}
@@ -67,6 +67,12 @@ func (s Segment) At(t float64) Point {
 	return Point{s.Start.X + t*(s.End.X-s.Start.X), s.Start.Y + t*(s.End.Y-s.Start.Y)}
 }
 
+// Derivative returns the derivative of the segment with respect to t,
+// which is constant.
+func (s Segment) Derivative(t float64) Vector {
+	return Vec(s.Start, s.End)
+}
+
 // Length returns the length of the segment.
 func (s Segment) Length() float64 {
 	if s.Start == s.End {
@@ -80,6 +86,11 @@ func (s Segment) ZeroLength() bool {
 	return s.Start == s.End
 }
 
+// Bounds returns the smallest AABB containing the segment.
+func (s Segment) Bounds() AABB {
+	return AABB{s.Start, s.Start}.extend(s.End)
+}
+
 // Intersection returns a segment representing the intersection, and whether a segment
 // exists at all. If the two segments intersect at only one point, then Segment contains
 // that point as both the Start and End. That is, the length is zero.
@@ -158,29 +169,6 @@ func inDisk(a, b, p Point) bool {
 	return Vec(p, a).Dot(Vec(p, b)) <= 0
 }
 
-// Line represents an infinite line of the form y=mx+b where m is the slope
-// and b is the y-intercept.
-type Line struct {
-	M, B float64
-}
-
-// LineFromPoints computes the Line that is intersects the two provided points.
-func LineFromPoints(p0, p1 Point) Line {
-	r := 1 / (p1.X - p0.X)
-	return Line{(p1.Y - p0.Y) * r, (p1.X*p0.Y - p0.X*p1.Y) * r}
-}
-
-// Intercept returns the intersection point of the two lines.
-//
-// Returns false if the lines do not intersect, or if they're identical (intersect at every point).
-func (l0 Line) Intercept(l1 Line) (Point, bool) {
-	if l1.M == l0.M {
-		return Point{}, false
-	}
-	r := 1 / (l1.M - l0.M)
-	return Point{(l0.B - l1.B) * r, (l1.M*l0.B - l0.M*l1.B) * r}, true
-}
-
 var Zero = Vector{0, 0}
 
 // Vector is a two-dimensional vector.
@@ -350,6 +338,16 @@ func (a AABB) Intersects(b AABB) bool {
 	return !(a.Max.X <= b.Min.X || a.Min.X >= b.Max.X || a.Max.Y <= b.Min.Y || a.Min.Y >= b.Max.Y)
 }
 
+// Contains returns true if p lies within the AABB or on its boundary.
+func (a AABB) Contains(p Point) bool {
+	return p.X >= a.Min.X && p.X <= a.Max.X && p.Y >= a.Min.Y && p.Y <= a.Max.Y
+}
+
+// Union returns the smallest AABB containing both a and b.
+func (a AABB) Union(b AABB) AABB {
+	return a.extend(b.Min).extend(b.Max)
+}
+
 // Penetration returns a vector representing the degree and direction of penetration
 // of a to b. Returns the zero vector if the two do not intersect.
 func (a AABB) Penetration(b AABB) Vector {
@@ -380,6 +378,14 @@ func (a AABB) MinkowskiDiff(b AABB) AABB {
 	return Dim(a.Dx()+b.Dx(), a.Dy()+b.Dy()).AABB(Pt(a.Min.X-b.Max.X, a.Min.Y-b.Max.Y))
 }
 
+// extend returns the smallest AABB containing both a and p.
+func (a AABB) extend(p Point) AABB {
+	return AABB{
+		Pt(min(a.Min.X, p.X), min(a.Min.Y, p.Y)),
+		Pt(max(a.Max.X, p.X), max(a.Max.Y, p.Y)),
+	}
+}
+
 // Left returns the left edge of the AABB.
 func (a AABB) Left() Segment {
 	return Seg(a.Min, Pt(a.Min.X, a.Max.Y))
@@ -0,0 +1,390 @@
+package geom
+
+import "math"
+
+// Shape is a convex shape described by its support function.
+//
+// Shapes may be combined arbitrarily with [Overlaps], [Distance],
+// and [Penetration].
+type Shape interface {
+	// Support returns a point in the shape that is furthest along
+	// the direction d. d need not be a unit vector.
+	Support(d Vector) Point
+}
+
+// rounded is implemented by shapes that are best described as some
+// core shape inflated by a radius, like Circle and Capsule.
+//
+// Handling the radius separately lets GJK and EPA work on polygonal
+// cores, which converge exactly and quickly, instead of on curved
+// boundaries, which do not.
+type rounded interface {
+	rounded() (core Shape, radius float64)
+}
+
+func splitRadius(s Shape) (Shape, float64) {
+	if r, ok := s.(rounded); ok {
+		return r.rounded()
+	}
+	return s, 0
+}
+
+// pointShape is a Shape consisting of a single point.
+type pointShape Point
+
+func (p pointShape) Support(Vector) Point {
+	return Point(p)
+}
+
+// Support implements Shape.
+func (a AABB) Support(d Vector) Point {
+	p := a.Min
+	if d.X >= 0 {
+		p.X = a.Max.X
+	}
+	if d.Y >= 0 {
+		p.Y = a.Max.Y
+	}
+	return p
+}
+
+// Support implements Shape.
+func (s Segment) Support(d Vector) Point {
+	if s.Start.Vector().Dot(d) >= s.End.Vector().Dot(d) {
+		return s.Start
+	}
+	return s.End
+}
+
+// Support implements Shape.
+//
+// The polygon must be convex for it to be used as a Shape, and must not
+// be empty. [Overlaps], [Distance], and [Penetration] treat an empty
+// polygon as containing no points, without calling Support.
+func (p Polygon) Support(d Vector) Point {
+	best := p[0]
+	bestDot := best.Vector().Dot(d)
+	for _, pt := range p[1:] {
+		if dot := pt.Vector().Dot(d); dot > bestDot {
+			best, bestDot = pt, dot
+		}
+	}
+	return best
+}
+
+// Overlaps returns true if the two convex shapes overlap.
+//
+// Shapes that only touch may be reported either way.
+func Overlaps(a, b Shape) bool {
+	if isEmpty(a) || isEmpty(b) {
+		return false
+	}
+	ca, ra := splitRadius(a)
+	cb, rb := splitRadius(b)
+	var s simplex
+	d := s.gjk(ca, cb)
+	if ra+rb == 0 {
+		return d == 0
+	}
+	return d < ra+rb
+}
+
+// Distance returns the distance between two convex shapes, as well as
+// the closest point in each shape to the other.
+//
+// If the shapes overlap, the distance is zero and the returned points
+// are unspecified. If either shape is an empty polygon, the distance is
+// infinite.
+func Distance(a, b Shape) (dist float64, pa, pb Point) {
+	if isEmpty(a) || isEmpty(b) {
+		return math.Inf(1), Point{}, Point{}
+	}
+	ca, ra := splitRadius(a)
+	cb, rb := splitRadius(b)
+	var s simplex
+	d := s.gjk(ca, cb)
+	pa, pb = s.witnesses()
+	if d <= ra+rb {
+		return 0, pa, pb
+	}
+	n := Vec(pa, pb).Scale(1 / d)
+	return d - ra - rb, pa.Add(n.Scale(ra)), pb.Add(n.Scale(-rb))
+}
+
+// Penetration returns a Contact describing the penetration of convex
+// shape a into convex shape b, using GJK and EPA.
+//
+// Returns false if the two do not overlap.
+func Penetration(a, b Shape) (Contact, bool) {
+	if isEmpty(a) || isEmpty(b) {
+		return Contact{}, false
+	}
+	ca, ra := splitRadius(a)
+	cb, rb := splitRadius(b)
+	r := ra + rb
+	var s simplex
+	d := s.gjk(ca, cb)
+	if d >= r && (d > 0 || r > 0) {
+		return Contact{}, false
+	}
+	if d > 0 {
+		// The cores are disjoint, only the radii overlap.
+		pa, pb := s.witnesses()
+		n := Vec(pb, pa).Scale(1 / d)
+		return Contact{Normal: n, Depth: r - d, Point: pb.Add(n.Scale(rb))}, true
+	}
+	n, depth, ok := s.epa(ca, cb)
+	if !ok {
+		// The Minkowski difference of the cores has no area,
+		// so only the radii contribute to the penetration.
+		if r == 0 {
+			return Contact{}, false
+		}
+		_, pb := s.witnesses()
+		return Contact{Normal: n, Depth: r, Point: pb}, true
+	}
+	_, pb := s.witnesses()
+	return Contact{Normal: n.Neg(), Depth: depth + r, Point: pb.Add(n.Scale(-rb))}, true
+}
+
+// isEmpty returns true if s is a polygon with no vertices.
+func isEmpty(s Shape) bool {
+	p, ok := s.(Polygon)
+	return ok && len(p) == 0
+}
+
+const (
+	gjkMaxIters = 64
+	gjkEpsilon  = 1e-12
+)
+
+// simplexVertex is a vertex of the Minkowski difference a-b
+// along with the support points that produced it.
+type simplexVertex struct {
+	a, b Point
+	w    Vector
+}
+
+func support(a, b Shape, d Vector) simplexVertex {
+	pa := a.Support(d)
+	pb := b.Support(d.Neg())
+	return simplexVertex{pa, pb, Vec(pb, pa)}
+}
+
+// simplex is a GJK simplex in the Minkowski difference of two shapes.
+type simplex struct {
+	v      [3]simplexVertex
+	lambda [3]float64
+	n      int
+}
+
+// gjk finds the closest point in the Minkowski difference of a and b
+// to the origin, and returns its distance to the origin.
+//
+// On return, s describes the closest point as a convex combination
+// of its vertices.
+func (s *simplex) gjk(a, b Shape) float64 {
+	s.v[0] = support(a, b, Vector{1, 0})
+	s.lambda[0] = 1
+	s.n = 1
+	for range gjkMaxIters {
+		s.solve()
+		if s.n == 3 {
+			return 0
+		}
+		c := s.closest()
+		c2 := c.Length2()
+		if c2 <= gjkEpsilon*gjkEpsilon {
+			return 0
+		}
+		w := support(a, b, c.Neg())
+		for i := range s.n {
+			if s.v[i].w == w.w {
+				return math.Sqrt(c2)
+			}
+		}
+		if c2-w.w.Dot(c) <= gjkEpsilon*c2 {
+			return math.Sqrt(c2)
+		}
+		s.v[s.n] = w
+		s.n++
+	}
+	return s.closest().Length()
+}
+
+// closest returns the point in the simplex closest to the origin.
+func (s *simplex) closest() Vector {
+	var c Vector
+	for i := range s.n {
+		c = c.Add(s.v[i].w.Scale(s.lambda[i]))
+	}
+	return c
+}
+
+// witnesses returns the points in each shape that produce the closest point.
+func (s *simplex) witnesses() (pa, pb Point) {
+	var va, vb Vector
+	for i := range s.n {
+		va = va.Add(s.v[i].a.Vector().Scale(s.lambda[i]))
+		vb = vb.Add(s.v[i].b.Vector().Scale(s.lambda[i]))
+	}
+	return va.Point(Origin), vb.Point(Origin)
+}
+
+// solve reduces the simplex to the smallest subset of its vertices
+// that contains its closest point to the origin, and computes the
+// barycentric coordinates of that point.
+func (s *simplex) solve() {
+	switch s.n {
+	case 1:
+		s.lambda[0] = 1
+	case 2:
+		s.solve2(0, 1)
+	case 3:
+		s.solve3()
+	}
+}
+
+func (s *simplex) keep(i int) {
+	s.v[0] = s.v[i]
+	s.lambda[0] = 1
+	s.n = 1
+}
+
+func (s *simplex) solve2(i, j int) {
+	a, b := s.v[i], s.v[j]
+	e := b.w.Sub(a.w)
+	ee := e.Dot(e)
+	if ee == 0 {
+		s.keep(i)
+		return
+	}
+	t := -a.w.Dot(e) / ee
+	switch {
+	case t <= 0:
+		s.keep(i)
+	case t >= 1:
+		s.keep(j)
+	default:
+		s.v[0], s.v[1] = a, b
+		s.lambda[0], s.lambda[1] = 1-t, t
+		s.n = 2
+	}
+}
+
+func (s *simplex) solve3() {
+	// Closest point on a triangle to the origin, by Voronoi region.
+	// See Ericson, Real-Time Collision Detection, section 5.1.5.
+	a, b, c := s.v[0].w, s.v[1].w, s.v[2].w
+	ab, ac := b.Sub(a), c.Sub(a)
+	d1, d2 := -ab.Dot(a), -ac.Dot(a)
+	if d1 <= 0 && d2 <= 0 {
+		s.keep(0)
+		return
+	}
+	d3, d4 := -ab.Dot(b), -ac.Dot(b)
+	if d3 >= 0 && d4 <= d3 {
+		s.keep(1)
+		return
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		s.solve2(0, 1)
+		return
+	}
+	d5, d6 := -ab.Dot(c), -ac.Dot(c)
+	if d6 >= 0 && d5 <= d6 {
+		s.keep(2)
+		return
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		s.solve2(0, 2)
+		return
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		s.solve2(1, 2)
+		return
+	}
+	denom := va + vb + vc
+	if denom == 0 {
+		// Degenerate triangle; the closest point lies on an edge.
+		s.solve2(0, 1)
+		return
+	}
+	v, w := vb/denom, vc/denom
+	s.lambda = [3]float64{1 - v - w, v, w}
+}
+
+// epa expands the simplex into a polytope within the Minkowski difference
+// of a and b and finds the edge closest to the origin.
+//
+// It must be called after gjk reports that the origin is contained in
+// the Minkowski difference. It returns the outward normal of the closest
+// edge and its distance from the origin, and leaves s describing the
+// closest point on that edge. Returns false if the Minkowski
+// difference has no area, in which case the normal is merely some vector
+// perpendicular to it.
+func (s *simplex) epa(a, b Shape) (Vector, float64, bool) {
+	poly := make([]simplexVertex, s.n, 16)
+	copy(poly, s.v[:s.n])
+
+	// Grow the simplex to a triangle.
+	if len(poly) == 1 {
+		for _, d := range [...]Vector{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			if w := support(a, b, d); w.w != poly[0].w {
+				poly = append(poly, w)
+				break
+			}
+		}
+		if len(poly) == 1 {
+			return Vector{1, 0}, 0, false
+		}
+	}
+	if len(poly) == 2 {
+		n := poly[1].w.Sub(poly[0].w).RightNormal()
+		w := support(a, b, n)
+		if crossMag(poly[1].w.Sub(poly[0].w), w.w.Sub(poly[0].w)) == 0 {
+			w = support(a, b, n.Neg())
+		}
+		if crossMag(poly[1].w.Sub(poly[0].w), w.w.Sub(poly[0].w)) == 0 {
+			return n.Normalize(), 0, false
+		}
+		poly = append(poly, w)
+	}
+	if crossMag(poly[1].w.Sub(poly[0].w), poly[2].w.Sub(poly[0].w)) < 0 {
+		poly[1], poly[2] = poly[2], poly[1]
+	}
+
+	var normal Vector
+	var dist float64
+	var edge [2]simplexVertex
+	for range gjkMaxIters {
+		// Find the edge closest to the origin. The polytope is
+		// counter-clockwise, so right normals point outward.
+		idx := -1
+		dist = math.Inf(1)
+		for i := range poly {
+			e := poly[(i+1)%len(poly)].w.Sub(poly[i].w)
+			if e == Zero {
+				continue
+			}
+			n := e.RightNormal().Normalize()
+			if d := n.Dot(poly[i].w); d < dist {
+				idx, dist, normal = i, d, n
+			}
+		}
+		edge = [2]simplexVertex{poly[idx], poly[(idx+1)%len(poly)]}
+		w := support(a, b, normal)
+		if w.w.Dot(normal)-dist <= gjkEpsilon*max(1, dist) {
+			break
+		}
+		poly = append(poly, simplexVertex{})
+		copy(poly[idx+2:], poly[idx+1:])
+		poly[idx+1] = w
+	}
+	s.v[0], s.v[1] = edge[0], edge[1]
+	s.solve2(0, 1)
+	return normal, max(dist, 0), true
+}
@@ -0,0 +1,171 @@
+package geom
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// ConvexHull returns the smallest convex polygon containing all the points,
+// in counter-clockwise order.
+//
+// Duplicate points and points that lie along the hull's edges are omitted,
+// so the hull of a set of collinear points has just its two extremes, and
+// the hull of a single repeated point has just that point.
+func ConvexHull(pts []Point) Polygon {
+	// See Andrew's monotone chain algorithm.
+	sorted := slices.Clone(pts)
+	slices.SortFunc(sorted, func(a, b Point) int {
+		if c := cmp.Compare(a.X, b.X); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Y, b.Y)
+	})
+	sorted = slices.Compact(sorted)
+	if len(sorted) < 3 {
+		return Polygon(sorted)
+	}
+	hull := make(Polygon, 0, 2*len(sorted))
+	chain := func(pts []Point) {
+		base := len(hull)
+		for _, p := range pts {
+			for len(hull) >= base+2 && orient(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		// The last point begins the other chain.
+		hull = hull[:len(hull)-1]
+	}
+	chain(sorted)
+	slices.Reverse(sorted)
+	chain(sorted)
+	return hull
+}
+
+// MinAreaRect returns the smallest-area rectangle containing all
+// the points.
+//
+// One side of the rectangle always lies along an edge of the points'
+// convex hull. If the points are collinear, the rectangle has zero height.
+func MinAreaRect(pts []Point) OBB {
+	h := ConvexHull(pts)
+	switch len(h) {
+	case 0:
+		return OBB{}
+	case 1:
+		return OBB{Center: h[0]}
+	case 2:
+		v := Vec(h[0], h[1])
+		return OBB{Seg(h[0], h[1]).At(0.5), Dim(v.Length()/2, 0), math.Atan2(v.Y, v.X)}
+	}
+
+	// Rotating calipers: for each edge of the hull, track the points
+	// furthest ahead along it, furthest behind it, and furthest from it.
+	n := len(h)
+	next := func(i int) int { return (i + 1) % n }
+	var best OBB
+	bestArea := math.Inf(1)
+	right, top, left := 0, 0, 0
+	for i := range n {
+		u := Vec(h[i], h[next(i)]).Normalize()
+		v := leftNormal(u)
+		proj := func(j int, axis Vector) float64 {
+			return Vec(h[i], h[j]).Dot(axis)
+		}
+		if i == 0 {
+			right = 1
+		}
+		for steps := 0; steps < n && proj(next(right), u) > proj(right, u); steps++ {
+			right = next(right)
+		}
+		if i == 0 {
+			top = right
+		}
+		for steps := 0; steps < n && proj(next(top), v) > proj(top, v); steps++ {
+			top = next(top)
+		}
+		if i == 0 {
+			left = top
+		}
+		for steps := 0; steps < n && proj(next(left), u) < proj(left, u); steps++ {
+			left = next(left)
+		}
+		lo, hi, height := proj(left, u), proj(right, u), proj(top, v)
+		if area := (hi - lo) * height; area < bestArea {
+			bestArea = area
+			best = OBB{
+				Center:   h[i].Add(u.Scale((lo + hi) / 2)).Add(v.Scale(height / 2)),
+				Half:     Dim((hi-lo)/2, height/2),
+				Rotation: math.Atan2(u.Y, u.X),
+			}
+		}
+	}
+	return best
+}
+
+// MinEnclosingCircle returns the smallest circle containing all the points.
+func MinEnclosingCircle(pts []Point) Circle {
+	// See Welzl's algorithm. Only the hull's vertices can lie on the
+	// circle, and visiting them in random order keeps the expected
+	// running time linear. The order is seeded, so the result is
+	// always the same for the same points.
+	h := ConvexHull(pts)
+	if len(h) == 0 {
+		return Circle{}
+	}
+	r := rand.New(rand.NewPCG(uint64(len(h)), 0))
+	r.Shuffle(len(h), func(i, j int) {
+		h[i], h[j] = h[j], h[i]
+	})
+	c := Circ(h[0], 0)
+	for i := 1; i < len(h); i++ {
+		if enclosed(c, h[i]) {
+			continue
+		}
+		c = Circ(h[i], 0)
+		for j := range i {
+			if enclosed(c, h[j]) {
+				continue
+			}
+			c = diameterCircle(h[i], h[j])
+			for k := range j {
+				if !enclosed(c, h[k]) {
+					c = circumcircle(h[i], h[j], h[k])
+				}
+			}
+		}
+	}
+	return c
+}
+
+// enclosed is like Circle.Contains, but allows for rounding error
+// in the circle's construction.
+func enclosed(c Circle, p Point) bool {
+	return Vec(c.Center, p).Length() <= c.Radius*(1+1e-12)+1e-12
+}
+
+// diameterCircle returns the circle with diameter ab.
+func diameterCircle(a, b Point) Circle {
+	return Circ(Seg(a, b).At(0.5), Vec(a, b).Length()/2)
+}
+
+// circumcircle returns the circle passing through a, b, and c.
+// If they're collinear, it returns the circle around the furthest pair.
+func circumcircle(a, b, c Point) Circle {
+	ab, ac := Vec(a, b), Vec(a, c)
+	d := 2 * crossMag(ab, ac)
+	if d == 0 {
+		best := diameterCircle(a, b)
+		for _, o := range [2]Circle{diameterCircle(a, c), diameterCircle(b, c)} {
+			if o.Radius > best.Radius {
+				best = o
+			}
+		}
+		return best
+	}
+	b2, c2 := ab.Length2(), ac.Length2()
+	v := Vector{(ac.Y*b2 - ab.Y*c2) / d, (ab.X*c2 - ac.X*b2) / d}
+	return Circ(a.Add(v), v.Length())
+}
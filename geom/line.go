@@ -0,0 +1,123 @@
+package geom
+
+import "math"
+
+// Line represents an infinite line that passes through the point Origin and
+// extends in both directions along Dir.
+//
+// Unlike slope-intercept form, this representation describes lines of
+// any orientation, including vertical ones. Dir need not be a unit vector,
+// but must not be zero.
+//
+// Left and right, looking along Dir, are defined in a coordinate system
+// where Y grows upward. In screen coordinates where Y grows downward, the
+// left side of a line appears on its right on screen, and vice versa.
+type Line struct {
+	Origin Point
+	Dir    Vector
+}
+
+// LineFromPoints computes the Line that intersects the two provided points.
+//
+// The points must not be identical.
+func LineFromPoints(p0, p1 Point) Line {
+	return Line{p0, Vec(p0, p1)}
+}
+
+// SlopeIntercept creates a Line of the form y=mx+b where m is the slope
+// and b is the y-intercept.
+func SlopeIntercept(m, b float64) Line {
+	return Line{Pt(0, b), Vector{1, m}}
+}
+
+// SlopeIntercept returns the slope and y-intercept of the line.
+//
+// Returns false if the line is vertical.
+func (l Line) SlopeIntercept() (m, b float64, ok bool) {
+	if l.Dir.X == 0 {
+		return 0, 0, false
+	}
+	m = l.Dir.Y / l.Dir.X
+	return m, l.Origin.Y - m*l.Origin.X, true
+}
+
+// Normal returns the unit normal of the line, pointing to its left.
+func (l Line) Normal() Vector {
+	return l.Dir.RightNormal().Neg().Normalize()
+}
+
+// SignedDistance returns the distance from the line to p. The distance is
+// positive if p lies to the left of the line, looking along Dir, and negative
+// if it lies to the right.
+func (l Line) SignedDistance(p Point) float64 {
+	return crossMag(l.Dir, Vec(l.Origin, p)) / l.Dir.Length()
+}
+
+// Distance returns the distance from the line to p.
+func (l Line) Distance(p Point) float64 {
+	return math.Abs(l.SignedDistance(p))
+}
+
+// Side returns 1 if p lies to the left of the line looking along Dir,
+// -1 if it lies to the right, and 0 if it lies on the line.
+func (l Line) Side(p Point) int {
+	c := crossMag(l.Dir, Vec(l.Origin, p))
+	switch {
+	case c > 0:
+		return 1
+	case c < 0:
+		return -1
+	}
+	return 0
+}
+
+// Project returns the point on the line closest to p.
+func (l Line) Project(p Point) Point {
+	return l.Origin.Add(Vec(l.Origin, p).ProjectOnto(l.Dir))
+}
+
+// Parallel returns true if the two lines are parallel. Coincident lines
+// are also parallel.
+func (l0 Line) Parallel(l1 Line) bool {
+	return crossMag(l0.Dir, l1.Dir) == 0
+}
+
+// Coincident returns true if the two lines are the same line.
+func (l0 Line) Coincident(l1 Line) bool {
+	return l0.Parallel(l1) && l0.Side(l1.Origin) == 0
+}
+
+// Intercept returns the intersection point of the two lines.
+//
+// Returns false if the lines do not intersect, or if they're identical (intersect at every point).
+func (l0 Line) Intercept(l1 Line) (Point, bool) {
+	d := crossMag(l0.Dir, l1.Dir)
+	if d == 0 {
+		return Point{}, false
+	}
+	t := crossMag(Vec(l0.Origin, l1.Origin), l1.Dir) / d
+	return l0.Origin.Add(l0.Dir.Scale(t)), true
+}
+
+// IntersectSegment returns a segment representing the intersection of the
+// line and s, and whether they intersect at all.
+//
+// Like [Segment.Intersection], if the two intersect at only one point, then
+// the returned Segment contains that point as both the Start and End.
+// If s lies on the line, s is returned.
+func (l Line) IntersectSegment(s Segment) (Segment, bool) {
+	c0 := crossMag(l.Dir, Vec(l.Origin, s.Start))
+	c1 := crossMag(l.Dir, Vec(l.Origin, s.End))
+	switch {
+	case c0 == 0 && c1 == 0:
+		return s, true
+	case c0 == 0:
+		return Seg(s.Start, s.Start), true
+	case c1 == 0:
+		return Seg(s.End, s.End), true
+	case (c0 < 0) == (c1 < 0):
+		return Segment{}, false
+	}
+	p := s.At(c0 / (c0 - c1))
+	return Seg(p, p), true
+}
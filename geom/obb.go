@@ -0,0 +1,117 @@
+package geom
+
+import "math"
+
+// OBB describes an oriented bounding box: a rectangle centered at Center
+// that extends Half.X along its own X axis and Half.Y along its own Y axis,
+// and is rotated anticlockwise by Rotation radians.
+//
+// OBB implements Shape.
+type OBB struct {
+	Center   Point
+	Half     Dimensions
+	Rotation float64
+}
+
+// OBBFromAABB returns the OBB covered by a after it's transformed by t.
+//
+// If t skews, the transformed box is a parallelogram rather than a
+// rectangle. The result is then the rectangle with the same center and
+// area whose X axis runs along a's transformed horizontal edges.
+func OBBFromAABB(a AABB, t Transform) OBB {
+	h := Vector{a.Dx() / 2, a.Dy() / 2}
+	x := t.ApplyVector(Vector{h.X, 0})
+	y := t.ApplyVector(Vector{0, h.Y})
+	center := t.Apply(a.Center())
+	hx := x.Length()
+	if hx == 0 {
+		// Orient the box by its Y axis instead.
+		return OBB{center, Dim(0, y.Length()), math.Atan2(-y.X, y.Y)}
+	}
+	return OBB{center, Dim(hx, math.Abs(crossMag(x, y))/hx), math.Atan2(x.Y, x.X)}
+}
+
+// Axes returns unit vectors along the box's X and Y axes.
+func (o OBB) Axes() (x, y Vector) {
+	cos, sin := math.Cos(o.Rotation), math.Sin(o.Rotation)
+	return Vector{cos, sin}, Vector{-sin, cos}
+}
+
+// Corners returns the corners of the box in counter-clockwise order,
+// starting from the corner that would be the minimum of an unrotated box.
+func (o OBB) Corners() [4]Point {
+	x, y := o.Axes()
+	x, y = x.Scale(o.Half.X), y.Scale(o.Half.Y)
+	return [4]Point{
+		o.Center.Add(x.Neg().Sub(y)),
+		o.Center.Add(x.Sub(y)),
+		o.Center.Add(x.Add(y)),
+		o.Center.Add(y.Sub(x)),
+	}
+}
+
+// Polygon returns the box as a counter-clockwise polygon. See [OBB.Corners].
+func (o OBB) Polygon() Polygon {
+	c := o.Corners()
+	return Polygon(c[:])
+}
+
+// Support implements Shape.
+func (o OBB) Support(d Vector) Point {
+	x, y := o.Axes()
+	p := o.Center
+	if x.Dot(d) >= 0 {
+		p = p.Add(x.Scale(o.Half.X))
+	} else {
+		p = p.Add(x.Scale(-o.Half.X))
+	}
+	if y.Dot(d) >= 0 {
+		p = p.Add(y.Scale(o.Half.Y))
+	} else {
+		p = p.Add(y.Scale(-o.Half.Y))
+	}
+	return p
+}
+
+// Bounds returns the smallest AABB containing the box.
+func (o OBB) Bounds() AABB {
+	x, y := o.Axes()
+	e := Vector{
+		math.Abs(x.X)*o.Half.X + math.Abs(y.X)*o.Half.Y,
+		math.Abs(x.Y)*o.Half.X + math.Abs(y.Y)*o.Half.Y,
+	}
+	return AABB{o.Center.Add(e.Neg()), o.Center.Add(e)}
+}
+
+// Contains returns true if p lies within the box or on its boundary.
+func (o OBB) Contains(p Point) bool {
+	x, y := o.Axes()
+	v := Vec(o.Center, p)
+	return math.Abs(v.Dot(x)) <= o.Half.X && math.Abs(v.Dot(y)) <= o.Half.Y
+}
+
+// Intersects returns true if the two boxes intersect.
+//
+// Like [AABB.Intersects], boxes that only touch are not considered intersecting.
+func (o OBB) Intersects(p OBB) bool {
+	_, ok := o.Penetration(p)
+	return ok
+}
+
+// IntersectsAABB returns true if the box and AABB intersect.
+func (o OBB) IntersectsAABB(b AABB) bool {
+	_, ok := o.PenetrationAABB(b)
+	return ok
+}
+
+// Penetration returns a Contact describing the penetration of o into p.
+// See [Polygon.Penetration].
+func (o OBB) Penetration(p OBB) (Contact, bool) {
+	return o.Polygon().Penetration(p.Polygon())
+}
+
+// PenetrationAABB returns a Contact describing the penetration of o into b.
+// See [Polygon.Penetration].
+func (o OBB) PenetrationAABB(b AABB) (Contact, bool) {
+	return o.Polygon().PenetrationAABB(b)
+}
@@ -0,0 +1,110 @@
+package pack
+
+import (
+	"math"
+	"slices"
+
+	"github.com/mknyszek/2d/geom"
+)
+
+// MaxRects is a bin that places rectangles using the MaxRects algorithm.
+//
+// It keeps track of every maximal free rectangle in the bin, which may
+// overlap one another, and places each new rectangle in a corner of the
+// free rectangle that it fits most snugly, leaving the least room along
+// its shorter side.
+type MaxRects struct {
+	size  geom.Dimensions
+	free  []geom.AABB
+	count int
+	used  float64
+}
+
+// NewMaxRects returns a new empty MaxRects bin of the given size.
+func NewMaxRects(size geom.Dimensions) *MaxRects {
+	return &MaxRects{size: size, free: []geom.AABB{size.AABB(geom.Origin)}}
+}
+
+// Insert places a rectangle of dimensions d in the bin, and returns the
+// area it covers. If rotate is true, the rectangle may be rotated a
+// quarter turn, which is reported by rotated. ok is false if there isn't
+// room for the rectangle.
+func (m *MaxRects) Insert(d geom.Dimensions, rotate bool) (r geom.AABB, rotated, ok bool) {
+	bestShort, bestLong := math.Inf(1), math.Inf(1)
+	for _, f := range m.free {
+		for k, o := range orientations(d, rotate) {
+			if !fits(o, f.Dim()) {
+				continue
+			}
+			dx, dy := f.Dx()-o.X, f.Dy()-o.Y
+			short, long := min(dx, dy), max(dx, dy)
+			if short < bestShort || (short == bestShort && long < bestLong) {
+				bestShort, bestLong = short, long
+				r, rotated, ok = o.AABB(f.Min), k == 1, true
+			}
+		}
+	}
+	if !ok {
+		return geom.AABB{}, false, false
+	}
+	m.place(r)
+	m.count++
+	m.used += d.X * d.Y
+	return r, rotated, true
+}
+
+// place removes r from the free rectangles.
+func (m *MaxRects) place(r geom.AABB) {
+	var split []geom.AABB
+	m.free = slices.DeleteFunc(m.free, func(f geom.AABB) bool {
+		if !f.Intersects(r) {
+			return false
+		}
+		// Keep the largest parts of f on each side of r.
+		if r.Min.X > f.Min.X {
+			split = append(split, geom.AABB{Min: f.Min, Max: geom.Pt(r.Min.X, f.Max.Y)})
+		}
+		if r.Max.X < f.Max.X {
+			split = append(split, geom.AABB{Min: geom.Pt(r.Max.X, f.Min.Y), Max: f.Max})
+		}
+		if r.Min.Y > f.Min.Y {
+			split = append(split, geom.AABB{Min: f.Min, Max: geom.Pt(f.Max.X, r.Min.Y)})
+		}
+		if r.Max.Y < f.Max.Y {
+			split = append(split, geom.AABB{Min: geom.Pt(f.Min.X, r.Max.Y), Max: f.Max})
+		}
+		return true
+	})
+	m.free = append(m.free, split...)
+
+	// Drop free rectangles that lie within others.
+	for i := 0; i < len(m.free); i++ {
+		for j := 0; j < len(m.free); j++ {
+			if i != j && encloses(m.free[j], m.free[i]) {
+				m.free = slices.Delete(m.free, i, i+1)
+				i--
+				break
+			}
+		}
+	}
+}
+
+// encloses returns true if b lies within a.
+func encloses(a, b geom.AABB) bool {
+	return a.Contains(b.Min) && a.Contains(b.Max)
+}
+
+func (m *MaxRects) empty() bool {
+	return m.count == 0
+}
+
+// Size returns the size of the bin.
+func (m *MaxRects) Size() geom.Dimensions {
+	return m.size
+}
+
+// Occupancy returns the fraction of the bin's area that's covered by
+// rectangles.
+func (m *MaxRects) Occupancy() float64 {
+	return m.used / (m.size.X * m.size.Y)
+}
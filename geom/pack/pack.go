@@ -0,0 +1,132 @@
+/*
+Package pack packs rectangles into bins, such as sprites into the pages
+of a texture atlas.
+
+[Pack] places a whole list of rectangles at once, opening as many bins
+as it needs. [MaxRects] and [Skyline] each manage a single bin, and
+accept rectangles one at a time, for atlases that are filled in as
+sprites are needed.
+*/
+package pack
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/mknyszek/2d/geom"
+)
+
+// Algorithm is an algorithm for packing rectangles into a bin.
+type Algorithm int
+
+const (
+	// AlgorithmMaxRects packs with [MaxRects]. It's slower, but usually
+	// packs more tightly.
+	AlgorithmMaxRects Algorithm = iota
+
+	// AlgorithmSkyline packs with [Skyline].
+	AlgorithmSkyline
+)
+
+// Options configures [Pack].
+type Options struct {
+	// Bin is the size of each bin.
+	Bin geom.Dimensions
+
+	// Algorithm is the algorithm used to place rectangles in each bin.
+	Algorithm Algorithm
+
+	// Rotate allows rectangles to be rotated a quarter turn if they fit
+	// better that way.
+	Rotate bool
+
+	// Padding is the space left between rectangles in the same bin.
+	Padding float64
+}
+
+// Placement describes where a rectangle was placed.
+type Placement struct {
+	// Bin is the index of the bin the rectangle was placed in.
+	Bin int
+
+	// AABB is the area the rectangle covers in the bin. The minimum
+	// corner of each bin is at the origin.
+	AABB geom.AABB
+
+	// Rotated reports whether the rectangle was rotated a quarter turn,
+	// which swaps its width and height.
+	Rotated bool
+}
+
+// Pack places rectangles of the given sizes into bins, opening new bins
+// as needed, and returns where each one was placed.
+//
+// Rectangles are placed from largest to smallest, which packs them more
+// tightly, and each goes into the first bin with room for it. Pack
+// returns an error if any rectangle is too large to fit in an empty bin.
+func Pack(sizes []geom.Dimensions, opts Options) ([]Placement, error) {
+	pad := geom.Dim(opts.Padding, opts.Padding)
+	binSize := opts.Bin.Vector().Add(pad.Vector())
+
+	order := make([]int, len(sizes))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		a, b := sizes[i], sizes[j]
+		if c := cmp.Compare(max(b.X, b.Y), max(a.X, a.Y)); c != 0 {
+			return c
+		}
+		return cmp.Compare(min(b.X, b.Y), min(a.X, a.Y))
+	})
+
+	var bins []bin
+	out := make([]Placement, len(sizes))
+	for _, i := range order {
+		d := geom.Dim(sizes[i].X+pad.X, sizes[i].Y+pad.Y)
+		placed := false
+		for b := 0; !placed; b++ {
+			if b == len(bins) {
+				if len(bins) > 0 && bins[b-1].empty() {
+					return nil, fmt.Errorf("pack: rectangle %d of size %v does not fit in a bin of size %v", i, sizes[i], opts.Bin)
+				}
+				size := geom.Dim(binSize.X, binSize.Y)
+				switch opts.Algorithm {
+				case AlgorithmSkyline:
+					bins = append(bins, NewSkyline(size))
+				default:
+					bins = append(bins, NewMaxRects(size))
+				}
+			}
+			r, rotated, ok := bins[b].Insert(d, opts.Rotate)
+			if !ok {
+				continue
+			}
+			r.Max = r.Max.Add(pad.Vector().Neg())
+			out[i] = Placement{Bin: b, AABB: r, Rotated: rotated}
+			placed = true
+		}
+	}
+	return out, nil
+}
+
+// bin is a single bin that rectangles are placed in one at a time.
+type bin interface {
+	Insert(d geom.Dimensions, rotate bool) (r geom.AABB, rotated, ok bool)
+	empty() bool
+}
+
+// fits returns true if a rectangle of dimensions d fits in free.
+func fits(d geom.Dimensions, free geom.Dimensions) bool {
+	return d.X <= free.X && d.Y <= free.Y
+}
+
+// orientations returns d, and d rotated if rotate is true and that would
+// make a difference.
+func orientations(d geom.Dimensions, rotate bool) []geom.Dimensions {
+	if rotate && d.X != d.Y {
+		return []geom.Dimensions{d, geom.Dim(d.Y, d.X)}
+	}
+	return []geom.Dimensions{d}
+}
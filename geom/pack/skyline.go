@@ -0,0 +1,110 @@
+package pack
+
+import (
+	"math"
+	"slices"
+
+	"github.com/mknyszek/2d/geom"
+)
+
+// Skyline is a bin that places rectangles using the skyline algorithm.
+//
+// It only keeps track of the outline of the rectangles placed so far,
+// as seen from the maximum Y edge of the bin, and places each new
+// rectangle on top of that outline where its top edge ends up lowest.
+// This is fast, but wastes any space left below the outline.
+type Skyline struct {
+	size  geom.Dimensions
+	nodes []skylineNode
+	count int
+	used  float64
+}
+
+// skylineNode is a horizontal segment of the skyline, which ends where
+// the next begins.
+type skylineNode struct {
+	x, y float64
+}
+
+// NewSkyline returns a new empty Skyline bin of the given size.
+func NewSkyline(size geom.Dimensions) *Skyline {
+	return &Skyline{size: size, nodes: []skylineNode{{0, 0}}}
+}
+
+// end returns the X coordinate at which node i ends.
+func (s *Skyline) end(i int) float64 {
+	if i+1 < len(s.nodes) {
+		return s.nodes[i+1].x
+	}
+	return s.size.X
+}
+
+// Insert places a rectangle of dimensions d in the bin, and returns the
+// area it covers. If rotate is true, the rectangle may be rotated a
+// quarter turn, which is reported by rotated. ok is false if there isn't
+// room for the rectangle.
+func (s *Skyline) Insert(d geom.Dimensions, rotate bool) (r geom.AABB, rotated, ok bool) {
+	best := math.Inf(1)
+	for i, n := range s.nodes {
+		for k, o := range orientations(d, rotate) {
+			// The rectangle rests on the highest node it spans.
+			if n.x+o.X > s.size.X {
+				continue
+			}
+			y := n.y
+			for j := i + 1; j < len(s.nodes) && s.nodes[j].x < n.x+o.X; j++ {
+				y = max(y, s.nodes[j].y)
+			}
+			if top := y + o.Y; top <= s.size.Y && top < best {
+				best = top
+				r, rotated, ok = o.AABB(geom.Pt(n.x, y)), k == 1, true
+			}
+		}
+	}
+	if !ok {
+		return geom.AABB{}, false, false
+	}
+	s.place(r)
+	s.count++
+	s.used += d.X * d.Y
+	return r, rotated, true
+}
+
+// place raises the skyline over r to its top edge.
+func (s *Skyline) place(r geom.AABB) {
+	if r.Dx() == 0 {
+		return
+	}
+	// Find the nodes that r covers, keeping the part of the last one
+	// that sticks out past r.
+	i := slices.IndexFunc(s.nodes, func(n skylineNode) bool { return n.x == r.Min.X })
+	j := i
+	for j+1 < len(s.nodes) && s.nodes[j+1].x <= r.Max.X {
+		j++
+	}
+	var tail []skylineNode
+	if r.Max.X < s.end(j) {
+		tail = []skylineNode{{r.Max.X, s.nodes[j].y}}
+	}
+	nodes := append(s.nodes[:i:i], skylineNode{r.Min.X, r.Max.Y})
+	nodes = append(nodes, tail...)
+	s.nodes = append(nodes, s.nodes[j+1:]...)
+
+	// Merge neighbors of equal height.
+	s.nodes = slices.CompactFunc(s.nodes, func(a, b skylineNode) bool { return a.y == b.y })
+}
+
+func (s *Skyline) empty() bool {
+	return s.count == 0
+}
+
+// Size returns the size of the bin.
+func (s *Skyline) Size() geom.Dimensions {
+	return s.size
+}
+
+// Occupancy returns the fraction of the bin's area that's covered by
+// rectangles.
+func (s *Skyline) Occupancy() float64 {
+	return s.used / (s.size.X * s.size.Y)
+}
@@ -0,0 +1,669 @@
+package geom
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Path is a sequence of commands that describe one or more subpaths,
+// each made up of lines and curves.
+//
+// Paths may be converted to and from SVG path data with [ParsePath]
+// and [Path.String].
+//
+// If a path does not begin with MoveTo, it begins at Origin.
+type Path struct {
+	cmds []Command
+}
+
+// Op is the operation performed by a path Command.
+type Op uint8
+
+const (
+	// MoveTo begins a new subpath at Points[0].
+	MoveTo Op = iota
+
+	// LineTo draws a line to Points[0].
+	LineTo
+
+	// QuadTo draws a quadratic Bézier curve to Points[1] with
+	// Points[0] as the control point.
+	QuadTo
+
+	// CubicTo draws a cubic Bézier curve to Points[2] with
+	// Points[0] and Points[1] as the control points.
+	CubicTo
+
+	// ArcTo draws an elliptical arc to Points[0] as described by Arc.
+	ArcTo
+
+	// Close draws a line back to the start of the subpath and ends it.
+	Close
+)
+
+// points returns the number of Points used by commands with this Op.
+func (op Op) points() int {
+	switch op {
+	case QuadTo:
+		return 2
+	case CubicTo:
+		return 3
+	case Close:
+		return 0
+	}
+	return 1
+}
+
+// Command is a single command in a Path.
+type Command struct {
+	Op     Op
+	Points [3]Point
+
+	// Arc describes the ellipse for ArcTo commands.
+	Arc ArcParams
+}
+
+// ArcParams describes an elliptical arc in the endpoint parameterization
+// used by SVG. The arc's start and end points are given by the path.
+type ArcParams struct {
+	// RadiusX and RadiusY are the radii of the ellipse. They're scaled up
+	// uniformly if they're too small for the ellipse to reach the end point.
+	RadiusX, RadiusY float64
+
+	// Rotation is the angle of the ellipse's X axis relative to the X axis,
+	// in radians.
+	Rotation float64
+
+	// Large selects the arc that spans more than 180 degrees.
+	Large bool
+
+	// Sweep selects the arc that goes in the direction of increasing angle.
+	Sweep bool
+}
+
+// End returns the point the command ends at.
+//
+// The end of a Close command is the start of the subpath, which the command
+// alone doesn't know, so End returns the zero Point for Close.
+func (c Command) End() Point {
+	switch c.Op {
+	case QuadTo:
+		return c.Points[1]
+	case CubicTo:
+		return c.Points[2]
+	case Close:
+		return Point{}
+	}
+	return c.Points[0]
+}
+
+// MoveTo begins a new subpath at pt.
+func (p *Path) MoveTo(pt Point) {
+	p.cmds = append(p.cmds, Command{Op: MoveTo, Points: [3]Point{pt}})
+}
+
+// LineTo draws a line from the current point to pt.
+func (p *Path) LineTo(pt Point) {
+	p.cmds = append(p.cmds, Command{Op: LineTo, Points: [3]Point{pt}})
+}
+
+// QuadTo draws a quadratic Bézier curve from the current point to pt,
+// using ctrl as the control point.
+func (p *Path) QuadTo(ctrl, pt Point) {
+	p.cmds = append(p.cmds, Command{Op: QuadTo, Points: [3]Point{ctrl, pt}})
+}
+
+// CubicTo draws a cubic Bézier curve from the current point to pt,
+// using ctrl0 and ctrl1 as the control points.
+func (p *Path) CubicTo(ctrl0, ctrl1, pt Point) {
+	p.cmds = append(p.cmds, Command{Op: CubicTo, Points: [3]Point{ctrl0, ctrl1, pt}})
+}
+
+// ArcTo draws an elliptical arc from the current point to pt.
+func (p *Path) ArcTo(arc ArcParams, pt Point) {
+	p.cmds = append(p.cmds, Command{Op: ArcTo, Points: [3]Point{pt}, Arc: arc})
+}
+
+// Close draws a line from the current point back to the start of the
+// current subpath, and ends the subpath.
+func (p *Path) Close() {
+	p.cmds = append(p.cmds, Command{Op: Close})
+}
+
+// Len returns the number of commands in the path.
+func (p *Path) Len() int {
+	return len(p.cmds)
+}
+
+// Commands returns an iterator over the commands in the path.
+func (p *Path) Commands() iter.Seq[Command] {
+	return func(yield func(Command) bool) {
+		for _, c := range p.cmds {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// walk calls f with each command in the path, along with the current
+// point before the command and the start of the current subpath.
+func (p *Path) walk(f func(c Command, cur, start Point) bool) {
+	var cur, start Point
+	for _, c := range p.cmds {
+		if !f(c, cur, start) {
+			return
+		}
+		switch c.Op {
+		case MoveTo:
+			cur, start = c.Points[0], c.Points[0]
+		case Close:
+			cur = start
+		default:
+			cur = c.End()
+		}
+	}
+}
+
+// curve returns the Curve drawn by c, given the current point and the
+// start of the current subpath. Returns false if c draws nothing.
+func (c Command) curve(cur, start Point) (Curve, bool) {
+	switch c.Op {
+	case LineTo:
+		return Seg(cur, c.Points[0]), true
+	case QuadTo:
+		return Bezier2(cur, c.Points[0], c.Points[1]), true
+	case CubicTo:
+		return Bezier3(cur, c.Points[0], c.Points[1], c.Points[2]), true
+	case ArcTo:
+		if cur == c.Points[0] {
+			return nil, false
+		}
+		if c.Arc.RadiusX == 0 || c.Arc.RadiusY == 0 {
+			return Seg(cur, c.Points[0]), true
+		}
+		return ArcFromEndpoints(cur, c.Arc, c.Points[0]), true
+	case Close:
+		if cur != start {
+			return Seg(cur, start), true
+		}
+	}
+	return nil, false
+}
+
+// Curves returns an iterator over the curves that make up the path, in order.
+//
+// Lines are produced as a [Segment], Bézier curves as a [QuadraticBezier]
+// or [CubicBezier], and arcs as an [EllipticalArc].
+func (p *Path) Curves() iter.Seq[Curve] {
+	return func(yield func(Curve) bool) {
+		p.walk(func(c Command, cur, start Point) bool {
+			if cv, ok := c.curve(cur, start); ok {
+				return yield(cv)
+			}
+			return true
+		})
+	}
+}
+
+// Bounds returns the smallest AABB containing the path.
+func (p *Path) Bounds() AABB {
+	var b AABB
+	first := true
+	add := func(a AABB) {
+		if first {
+			b, first = a, false
+			return
+		}
+		b = b.Union(a)
+	}
+	p.walk(func(c Command, cur, start Point) bool {
+		if c.Op == MoveTo {
+			add(AABB{c.Points[0], c.Points[0]})
+		}
+		if cv, ok := c.curve(cur, start); ok {
+			add(curveBounds(cv))
+		}
+		return true
+	})
+	return b
+}
+
+// curveBounds returns the bounds of the curve, approximating them
+// if the curve can't compute them itself.
+func curveBounds(c Curve) AABB {
+	if b, ok := c.(interface{ Bounds() AABB }); ok {
+		return b.Bounds()
+	}
+	var b AABB
+	first := true
+	for pt := range Flatten(c, 1e-6) {
+		if first {
+			b, first = AABB{pt, pt}, false
+		}
+		b = b.extend(pt)
+	}
+	return b
+}
+
+// Flatten returns an iterator over polylines approximating each subpath,
+// along with whether that subpath is closed. See [Flatten] for the meaning
+// of tolerance.
+//
+// The polyline for a closed subpath does not repeat its first point, so
+// it may be used directly as a [Polygon]. Subpaths that draw nothing are
+// omitted.
+func (p *Path) Flatten(tolerance float64) iter.Seq2[[]Point, bool] {
+	return func(yield func([]Point, bool) bool) {
+		var line []Point
+		flush := func(closed bool) bool {
+			if closed && len(line) > 1 && line[0] == line[len(line)-1] {
+				line = line[:len(line)-1]
+			}
+			ok := true
+			if len(line) > 1 {
+				ok = yield(line, closed)
+			}
+			line = nil
+			return ok
+		}
+		p.walk(func(c Command, cur, start Point) bool {
+			if c.Op == MoveTo {
+				return flush(false)
+			}
+			if cv, ok := c.curve(cur, start); ok {
+				skip := len(line) > 0
+				for pt := range Flatten(cv, tolerance) {
+					if !skip {
+						line = append(line, pt)
+					}
+					skip = false
+				}
+			}
+			if c.Op == Close {
+				if len(line) == 0 {
+					line = append(line, start)
+				}
+				return flush(true)
+			}
+			return true
+		})
+		flush(false)
+	}
+}
+
+// Translate returns a copy of the path moved in the direction of v.
+func (p *Path) Translate(v Vector) *Path {
+	return p.affine([4]float64{1, 0, 0, 1}, v)
+}
+
+// Scale returns a copy of the path scaled by sx and sy about the origin.
+func (p *Path) Scale(sx, sy float64) *Path {
+	return p.affine([4]float64{sx, 0, 0, sy}, Zero)
+}
+
+// Rotate returns a copy of the path rotated anticlockwise by rad radians
+// about the origin.
+func (p *Path) Rotate(rad float64) *Path {
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	return p.affine([4]float64{cos, -sin, sin, cos}, Zero)
+}
+
+// Transform returns a copy of the path transformed by t.
+func (p *Path) Transform(t Transform) *Path {
+	a, b, c, d, tx, ty := t.Elements()
+	return p.affine([4]float64{a, b, c, d}, Vector{tx, ty})
+}
+
+// affine returns a copy of the path with each point p mapped to m*p+t,
+// where m is a row-major 2x2 matrix.
+func (p *Path) affine(m [4]float64, t Vector) *Path {
+	apply := func(pt Point) Point {
+		return Pt(m[0]*pt.X+m[1]*pt.Y+t.X, m[2]*pt.X+m[3]*pt.Y+t.Y)
+	}
+	q := &Path{cmds: make([]Command, len(p.cmds))}
+	for i, c := range p.cmds {
+		for j := range c.Op.points() {
+			c.Points[j] = apply(c.Points[j])
+		}
+		if c.Op == ArcTo {
+			c.Arc = c.Arc.linear(m)
+		}
+		q.cmds[i] = c
+	}
+	return q
+}
+
+// linear returns the parameters of the arc's ellipse after applying the
+// linear transformation m, a row-major 2x2 matrix.
+func (a ArcParams) linear(m [4]float64) ArcParams {
+	// The ellipse is the unit circle transformed by m*R*S, where R is
+	// the ellipse's rotation and S scales by its radii. The singular
+	// value decomposition of that matrix gives the new rotation and radii.
+	cos, sin := math.Cos(a.Rotation), math.Sin(a.Rotation)
+	e00 := (m[0]*cos + m[1]*sin) * a.RadiusX
+	e01 := (-m[0]*sin + m[1]*cos) * a.RadiusY
+	e10 := (m[2]*cos + m[3]*sin) * a.RadiusX
+	e11 := (-m[2]*sin + m[3]*cos) * a.RadiusY
+	ee, f := (e00+e11)/2, (e00-e11)/2
+	g, h := (e10+e01)/2, (e10-e01)/2
+	q, r := math.Hypot(ee, h), math.Hypot(f, g)
+	a0, a1 := math.Atan2(g, f), math.Atan2(h, ee)
+	a.RadiusX = q + r
+	a.RadiusY = math.Abs(q - r)
+	a.Rotation = (a1 + a0) / 2
+	if m[0]*m[3]-m[1]*m[2] < 0 {
+		// Reflections reverse the direction of the arc.
+		a.Sweep = !a.Sweep
+	}
+	return a
+}
+
+// String returns the path as SVG path data, using only absolute commands.
+func (p *Path) String() string {
+	var sb strings.Builder
+	num := func(f float64) {
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	pts := func(pts ...Point) {
+		for _, pt := range pts {
+			num(pt.X)
+			num(pt.Y)
+		}
+	}
+	op := func(b byte) {
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteByte(b)
+	}
+	flag := func(b bool) {
+		if b {
+			num(1)
+		} else {
+			num(0)
+		}
+	}
+	for _, c := range p.cmds {
+		switch c.Op {
+		case MoveTo:
+			op('M')
+			pts(c.Points[0])
+		case LineTo:
+			op('L')
+			pts(c.Points[0])
+		case QuadTo:
+			op('Q')
+			pts(c.Points[0], c.Points[1])
+		case CubicTo:
+			op('C')
+			pts(c.Points[0], c.Points[1], c.Points[2])
+		case ArcTo:
+			op('A')
+			num(c.Arc.RadiusX)
+			num(c.Arc.RadiusY)
+			num(Deg(c.Arc.Rotation))
+			flag(c.Arc.Large)
+			flag(c.Arc.Sweep)
+			pts(c.Points[0])
+		case Close:
+			op('Z')
+		}
+	}
+	return sb.String()
+}
+
+// ParsePath parses SVG path data, the contents of a path element's "d" attribute.
+//
+// Relative, shorthand, and smooth commands are converted into their absolute
+// equivalents.
+func ParsePath(d string) (*Path, error) {
+	ps := pathScanner{s: d}
+	p := new(Path)
+	var cur, start, ctrl Point // ctrl is the last control point, for smooth curves.
+	var prev byte
+	for {
+		ps.skipSpace()
+		if ps.done() {
+			break
+		}
+		cmd := ps.s[ps.i]
+		if !strings.ContainsRune("MmLlHhVvCcSsQqTtAaZz", rune(cmd)) {
+			if prev == 0 || prev == 'Z' || prev == 'z' {
+				return nil, ps.errorf("expected command")
+			}
+			// Repeat the previous command. Repeated moves become lines.
+			cmd = prev
+			switch cmd {
+			case 'M':
+				cmd = 'L'
+			case 'm':
+				cmd = 'l'
+			}
+		} else {
+			ps.i++
+		}
+		rel := cmd >= 'a'
+		pt := func() (Point, error) {
+			x, err := ps.number()
+			if err != nil {
+				return Point{}, err
+			}
+			y, err := ps.number()
+			if err != nil {
+				return Point{}, err
+			}
+			if rel {
+				return Pt(cur.X+x, cur.Y+y), nil
+			}
+			return Pt(x, y), nil
+		}
+		var err error
+		var p0, p1, p2 Point
+		switch cmd {
+		case 'M', 'm':
+			if p0, err = pt(); err != nil {
+				return nil, err
+			}
+			p.MoveTo(p0)
+			cur, start = p0, p0
+		case 'L', 'l':
+			if p0, err = pt(); err != nil {
+				return nil, err
+			}
+			p.LineTo(p0)
+			cur = p0
+		case 'H', 'h', 'V', 'v':
+			v, err := ps.number()
+			if err != nil {
+				return nil, err
+			}
+			p0 = cur
+			switch cmd {
+			case 'H':
+				p0.X = v
+			case 'h':
+				p0.X += v
+			case 'V':
+				p0.Y = v
+			case 'v':
+				p0.Y += v
+			}
+			p.LineTo(p0)
+			cur = p0
+		case 'C', 'c':
+			if p0, err = pt(); err != nil {
+				return nil, err
+			}
+			if p1, err = pt(); err != nil {
+				return nil, err
+			}
+			if p2, err = pt(); err != nil {
+				return nil, err
+			}
+			p.CubicTo(p0, p1, p2)
+			ctrl, cur = p1, p2
+		case 'S', 's':
+			p0 = cur
+			if strings.IndexByte("CcSs", prev) >= 0 {
+				p0 = cur.Add(Vec(ctrl, cur))
+			}
+			if p1, err = pt(); err != nil {
+				return nil, err
+			}
+			if p2, err = pt(); err != nil {
+				return nil, err
+			}
+			p.CubicTo(p0, p1, p2)
+			ctrl, cur = p1, p2
+		case 'Q', 'q':
+			if p0, err = pt(); err != nil {
+				return nil, err
+			}
+			if p1, err = pt(); err != nil {
+				return nil, err
+			}
+			p.QuadTo(p0, p1)
+			ctrl, cur = p0, p1
+		case 'T', 't':
+			p0 = cur
+			if strings.IndexByte("QqTt", prev) >= 0 {
+				p0 = cur.Add(Vec(ctrl, cur))
+			}
+			if p1, err = pt(); err != nil {
+				return nil, err
+			}
+			p.QuadTo(p0, p1)
+			ctrl, cur = p0, p1
+		case 'A', 'a':
+			var arc ArcParams
+			var rot float64
+			if arc.RadiusX, err = ps.number(); err != nil {
+				return nil, err
+			}
+			if arc.RadiusY, err = ps.number(); err != nil {
+				return nil, err
+			}
+			if rot, err = ps.number(); err != nil {
+				return nil, err
+			}
+			arc.Rotation = Rad(rot)
+			if arc.Large, err = ps.flag(); err != nil {
+				return nil, err
+			}
+			if arc.Sweep, err = ps.flag(); err != nil {
+				return nil, err
+			}
+			if p0, err = pt(); err != nil {
+				return nil, err
+			}
+			arc.RadiusX, arc.RadiusY = math.Abs(arc.RadiusX), math.Abs(arc.RadiusY)
+			p.ArcTo(arc, p0)
+			cur = p0
+		case 'Z', 'z':
+			p.Close()
+			cur = start
+		}
+		prev = cmd
+	}
+	return p, nil
+}
+
+// pathScanner tokenizes SVG path data.
+type pathScanner struct {
+	s string
+	i int
+}
+
+func (ps *pathScanner) done() bool {
+	return ps.i >= len(ps.s)
+}
+
+func (ps *pathScanner) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid path data at offset %d: %s", ps.i, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips whitespace and at most one comma.
+func (ps *pathScanner) skipSpace() {
+	comma := false
+	for !ps.done() {
+		switch ps.s[ps.i] {
+		case ' ', '\t', '\n', '\r', '\f':
+		case ',':
+			if comma {
+				return
+			}
+			comma = true
+		default:
+			return
+		}
+		ps.i++
+	}
+}
+
+// number scans a number.
+func (ps *pathScanner) number() (float64, error) {
+	ps.skipSpace()
+	start := ps.i
+	digits := func() int {
+		n := 0
+		for !ps.done() && ps.s[ps.i] >= '0' && ps.s[ps.i] <= '9' {
+			ps.i++
+			n++
+		}
+		return n
+	}
+	sign := func() {
+		if !ps.done() && (ps.s[ps.i] == '+' || ps.s[ps.i] == '-') {
+			ps.i++
+		}
+	}
+	sign()
+	n := digits()
+	if !ps.done() && ps.s[ps.i] == '.' {
+		ps.i++
+		n += digits()
+	}
+	if n == 0 {
+		ps.i = start
+		return 0, ps.errorf("expected number")
+	}
+	if !ps.done() && (ps.s[ps.i] == 'e' || ps.s[ps.i] == 'E') {
+		mark := ps.i
+		ps.i++
+		sign()
+		if digits() == 0 {
+			// Not an exponent after all.
+			ps.i = mark
+		}
+	}
+	f, err := strconv.ParseFloat(ps.s[start:ps.i], 64)
+	if err != nil {
+		ps.i = start
+		return 0, ps.errorf("%v", err)
+	}
+	return f, nil
+}
+
+// flag scans an arc flag, which is a single 0 or 1 that need not be
+// separated from what follows.
+func (ps *pathScanner) flag() (bool, error) {
+	ps.skipSpace()
+	if !ps.done() {
+		switch ps.s[ps.i] {
+		case '0':
+			ps.i++
+			return false, nil
+		case '1':
+			ps.i++
+			return true, nil
+		}
+	}
+	return false, ps.errorf("expected flag")
+}
@@ -0,0 +1,249 @@
+package geom
+
+import (
+	"iter"
+	"math"
+)
+
+// Polygon is a closed polygon described by an ordered sequence of vertices.
+//
+// The last vertex is implicitly connected back to the first, so
+// a Polygon should not repeat its first vertex at the end.
+type Polygon []Point
+
+// Poly creates a new Polygon from the provided vertices.
+func Poly(pts ...Point) Polygon {
+	return Polygon(pts)
+}
+
+// Edge returns the i'th edge of the polygon, which starts at
+// the i'th vertex and ends at the next one.
+func (p Polygon) Edge(i int) Segment {
+	j := i + 1
+	if j == len(p) {
+		j = 0
+	}
+	return Seg(p[i], p[j])
+}
+
+// Edges returns an iterator over all the edges of the polygon.
+func (p Polygon) Edges() iter.Seq[Segment] {
+	return func(yield func(Segment) bool) {
+		for i := range p {
+			if !yield(p.Edge(i)) {
+				return
+			}
+		}
+	}
+}
+
+// SignedArea returns the signed area of the polygon.
+//
+// The area is positive if the vertices are in counter-clockwise order,
+// and negative if they are in clockwise order. See [Winding].
+func (p Polygon) SignedArea() float64 {
+	if len(p) < 3 {
+		return 0
+	}
+	var a float64
+	for i := range p {
+		e := p.Edge(i)
+		a += crossMag(e.Start.Vector(), e.End.Vector())
+	}
+	return a / 2
+}
+
+// Area returns the area enclosed by the polygon.
+//
+// For self-intersecting polygons, regions with opposite winding
+// cancel each other out.
+func (p Polygon) Area() float64 {
+	return math.Abs(p.SignedArea())
+}
+
+// Centroid returns the centroid (center of mass) of the polygon.
+//
+// If the polygon has no area, the average of its vertices is
+// returned instead.
+func (p Polygon) Centroid() Point {
+	if len(p) == 0 {
+		return Origin
+	}
+	// Accumulate relative to the first vertex to reduce rounding error.
+	o := p[0]
+	var a, cx, cy float64
+	for i := range p {
+		e := p.Edge(i)
+		v0, v1 := Vec(o, e.Start), Vec(o, e.End)
+		w := crossMag(v0, v1)
+		a += w
+		cx += (v0.X + v1.X) * w
+		cy += (v0.Y + v1.Y) * w
+	}
+	if a == 0 {
+		var sum Vector
+		for _, pt := range p {
+			sum = sum.Add(Vec(o, pt))
+		}
+		return o.Add(sum.Scale(1 / float64(len(p))))
+	}
+	return o.Add(Vector{cx, cy}.Scale(1 / (3 * a)))
+}
+
+// Winding describes the order of a polygon's vertices.
+//
+// Orientation is defined in a coordinate system where Y grows upward.
+// In screen coordinates where Y grows downward, CounterClockwise
+// polygons appear clockwise on screen, and vice versa.
+type Winding int
+
+const (
+	// Degenerate is the winding of a polygon with no area.
+	Degenerate Winding = iota
+	CounterClockwise
+	Clockwise
+)
+
+// Winding returns the order of the polygon's vertices, determined
+// by the sign of its area.
+func (p Polygon) Winding() Winding {
+	a := p.SignedArea()
+	switch {
+	case a > 0:
+		return CounterClockwise
+	case a < 0:
+		return Clockwise
+	}
+	return Degenerate
+}
+
+// Reverse returns a copy of the polygon with the order of its vertices reversed.
+func (p Polygon) Reverse() Polygon {
+	q := make(Polygon, len(p))
+	for i, pt := range p {
+		q[len(p)-1-i] = pt
+	}
+	return q
+}
+
+// Translate moves the polygon in the direction of the provided vector.
+func (p Polygon) Translate(v Vector) Polygon {
+	q := make(Polygon, len(p))
+	for i, pt := range p {
+		q[i] = pt.Add(v)
+	}
+	return q
+}
+
+// Convex returns true if the polygon is convex.
+//
+// Collinear and repeated vertices are permitted. Self-intersecting
+// polygons and polygons with no area are never convex.
+func (p Polygon) Convex() bool {
+	var edges []Vector
+	for e := range p.Edges() {
+		if !e.ZeroLength() {
+			edges = append(edges, Vec(e.Start, e.End))
+		}
+	}
+	if len(edges) < 3 {
+		return false
+	}
+	var sign, turn float64
+	for i, e0 := range edges {
+		e1 := edges[(i+1)%len(edges)]
+		c := crossMag(e0, e1)
+		if c != 0 {
+			if sign == 0 {
+				sign = math.Copysign(1, c)
+			} else if sign*c < 0 {
+				return false
+			}
+		}
+		turn += math.Atan2(c, e0.Dot(e1))
+	}
+	// A simple convex polygon turns exactly once around. Anything
+	// more means the boundary winds over itself.
+	return sign != 0 && math.Abs(math.Abs(turn)-2*math.Pi) < 1e-6
+}
+
+// FillRule determines which points are considered inside a polygon
+// whose boundary may overlap itself.
+type FillRule int
+
+const (
+	// NonZero considers a point inside if the polygon winds around
+	// it a non-zero number of times.
+	NonZero FillRule = iota
+
+	// EvenOdd considers a point inside if a ray from the point
+	// crosses the boundary an odd number of times.
+	EvenOdd
+)
+
+// WindingNumber returns the number of times the polygon winds around pt.
+//
+// Counter-clockwise loops count positively, while clockwise loops count
+// negatively. The result is unspecified for points on the boundary.
+func (p Polygon) WindingNumber(pt Point) int {
+	w := 0
+	for e := range p.Edges() {
+		if e.Start.Y <= pt.Y {
+			if e.End.Y > pt.Y && orient(e.Start, e.End, pt) > 0 {
+				w++
+			}
+		} else if e.End.Y <= pt.Y && orient(e.Start, e.End, pt) < 0 {
+			w--
+		}
+	}
+	return w
+}
+
+// Contains returns true if pt is inside the polygon according to the
+// provided fill rule.
+//
+// Points that lie exactly on the polygon's boundary are always
+// considered inside.
+func (p Polygon) Contains(pt Point, rule FillRule) bool {
+	for e := range p.Edges() {
+		if e.Contains(pt) {
+			return true
+		}
+	}
+	w := p.WindingNumber(pt)
+	if rule == EvenOdd {
+		return w%2 != 0
+	}
+	return w != 0
+}
+
+// Bounds returns the smallest AABB containing the polygon.
+func (p Polygon) Bounds() AABB {
+	if len(p) == 0 {
+		return AABB{}
+	}
+	b := AABB{p[0], p[0]}
+	for _, pt := range p[1:] {
+		b.Min.X = min(b.Min.X, pt.X)
+		b.Min.Y = min(b.Min.Y, pt.Y)
+		b.Max.X = max(b.Max.X, pt.X)
+		b.Max.Y = max(b.Max.Y, pt.Y)
+	}
+	return b
+}
+
+// IntersectsSegment returns true if the segment touches the polygon's
+// boundary or lies within it, according to the provided fill rule.
+func (p Polygon) IntersectsSegment(s Segment, rule FillRule) bool {
+	for e := range p.Edges() {
+		if _, ok := e.Intersection(s); ok {
+			return true
+		}
+	}
+	return p.Contains(s.Start, rule)
+}
+
+// Polygon returns the AABB as a counter-clockwise polygon.
+func (a AABB) Polygon() Polygon {
+	return Polygon{a.Min, Pt(a.Max.X, a.Min.Y), a.Max, Pt(a.Min.X, a.Max.Y)}
+}
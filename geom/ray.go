@@ -0,0 +1,224 @@
+package geom
+
+import "math"
+
+// Ray is a half-line that starts at Origin and extends in the direction Dir.
+//
+// Dir must be a unit vector. If Max is non-zero, the ray ends after
+// traveling that distance. Otherwise, it extends forever. A ray whose
+// Dir is zero or not finite has no direction, and misses everything.
+//
+// If a ray starts inside a shape, casting it against that shape reports
+// a hit at distance zero with a zero normal.
+type Ray struct {
+	Origin Point
+	Dir    Vector
+	Max    float64
+}
+
+// RayDir creates a new unbounded Ray from origin in the direction of dir.
+//
+// dir need not be a unit vector. If it's zero, the ray has no direction,
+// and misses everything.
+func RayDir(origin Point, dir Vector) Ray {
+	return Ray{Origin: origin, Dir: dir.Normalize()}
+}
+
+// RayTo creates a new Ray from origin that ends at p.
+//
+// If p is the same as origin, the ray has no direction, and misses
+// everything.
+func RayTo(origin, p Point) Ray {
+	v := Vec(origin, p)
+	return Ray{Origin: origin, Dir: v.Normalize(), Max: v.Length()}
+}
+
+// At returns the point at distance d along the ray.
+func (r Ray) At(d float64) Point {
+	return r.Origin.Add(r.Dir.Scale(d))
+}
+
+// Segment returns the ray as a segment, if it is bounded.
+func (r Ray) Segment() (Segment, bool) {
+	if r.Max == 0 {
+		return Segment{}, false
+	}
+	return Seg(r.Origin, r.At(r.Max)), true
+}
+
+// valid returns true if the ray has a direction.
+func (r Ray) valid() bool {
+	l := r.Dir.Length2()
+	return l > 0 && !math.IsInf(l, 1)
+}
+
+func (r Ray) limit() float64 {
+	if r.Max == 0 {
+		return math.Inf(1)
+	}
+	return r.Max
+}
+
+// Hit describes where a ray hit a shape.
+type Hit struct {
+	// Dist is the distance along the ray to the hit.
+	Dist float64
+
+	// Point is the point at which the ray hit the shape.
+	Point Point
+
+	// Normal is the unit surface normal of the shape at Point,
+	// facing the ray.
+	Normal Vector
+}
+
+func (r Ray) hit(d float64, n Vector) (Hit, bool) {
+	if d < 0 || d > r.limit() {
+		return Hit{}, false
+	}
+	return Hit{Dist: d, Point: r.At(d), Normal: n}, true
+}
+
+func (r Ray) inside() (Hit, bool) {
+	return Hit{Point: r.Origin}, true
+}
+
+// CastSegment returns where the ray first hits s, if at all.
+func (r Ray) CastSegment(s Segment) (Hit, bool) {
+	if !r.valid() {
+		return Hit{}, false
+	}
+	e := Vec(s.Start, s.End)
+	o := Vec(r.Origin, s.Start)
+	denom := crossMag(r.Dir, e)
+	if denom == 0 {
+		if crossMag(r.Dir, o) != 0 {
+			// Parallel, but not collinear.
+			return Hit{}, false
+		}
+		// Collinear: the nearest endpoint ahead of the origin is the hit.
+		t0, t1 := o.Dot(r.Dir), Vec(r.Origin, s.End).Dot(r.Dir)
+		if max(t0, t1) < 0 {
+			return Hit{}, false
+		}
+		return r.hit(max(0, min(t0, t1)), r.Dir.Neg())
+	}
+	t := crossMag(o, e) / denom
+	u := crossMag(o, r.Dir) / denom
+	if u < 0 || u > 1 {
+		return Hit{}, false
+	}
+	n := e.RightNormal().Normalize()
+	if n.Dot(r.Dir) > 0 {
+		n = n.Neg()
+	}
+	return r.hit(t, n)
+}
+
+// CastAABB returns where the ray first hits b, if at all.
+func (r Ray) CastAABB(b AABB) (Hit, bool) {
+	if !r.valid() {
+		return Hit{}, false
+	}
+	enter, exit := math.Inf(-1), math.Inf(1)
+	var normal Vector
+	for _, axis := range [2]struct {
+		o, d, lo, hi float64
+		n            Vector
+	}{
+		{r.Origin.X, r.Dir.X, b.Min.X, b.Max.X, Vector{1, 0}},
+		{r.Origin.Y, r.Dir.Y, b.Min.Y, b.Max.Y, Vector{0, 1}},
+	} {
+		if axis.d == 0 {
+			if axis.o < axis.lo || axis.o > axis.hi {
+				return Hit{}, false
+			}
+			continue
+		}
+		t0 := (axis.lo - axis.o) / axis.d
+		t1 := (axis.hi - axis.o) / axis.d
+		n := axis.n.Neg()
+		if t0 > t1 {
+			t0, t1 = t1, t0
+			n = axis.n
+		}
+		if t0 > enter {
+			enter, normal = t0, n
+		}
+		exit = min(exit, t1)
+	}
+	if enter > exit || exit < 0 {
+		return Hit{}, false
+	}
+	if enter < 0 {
+		return r.inside()
+	}
+	return r.hit(enter, normal)
+}
+
+// CastCircle returns where the ray first hits c, if at all.
+func (r Ray) CastCircle(c Circle) (Hit, bool) {
+	if !r.valid() {
+		return Hit{}, false
+	}
+	o := Vec(c.Center, r.Origin)
+	b := o.Dot(r.Dir)
+	cc := o.Length2() - c.Radius*c.Radius
+	if cc <= 0 {
+		return r.inside()
+	}
+	disc := b*b - cc
+	if b > 0 || disc < 0 {
+		return Hit{}, false
+	}
+	d := -b - math.Sqrt(disc)
+	h, ok := r.hit(d, Zero)
+	if ok {
+		h.Normal = Vec(c.Center, h.Point).Normalize()
+	}
+	return h, ok
+}
+
+// CastCapsule returns where the ray first hits c, if at all.
+func (r Ray) CastCapsule(c Capsule) (Hit, bool) {
+	if !r.valid() {
+		return Hit{}, false
+	}
+	if c.Contains(r.Origin) {
+		return r.inside()
+	}
+	best, found := r.CastCircle(Circ(c.Spine.Start, c.Radius))
+	try := func(h Hit, ok bool) {
+		if ok && (!found || h.Dist < best.Dist) {
+			best, found = h, true
+		}
+	}
+	try(r.CastCircle(Circ(c.Spine.End, c.Radius)))
+	if !c.Spine.ZeroLength() {
+		off := Vec(c.Spine.Start, c.Spine.End).RightNormal().Normalize().Scale(c.Radius)
+		try(r.CastSegment(Seg(c.Spine.Start.Add(off), c.Spine.End.Add(off))))
+		try(r.CastSegment(Seg(c.Spine.Start.Add(off.Neg()), c.Spine.End.Add(off.Neg()))))
+	}
+	return best, found
+}
+
+// CastPolygon returns where the ray first hits p, if at all.
+//
+// The polygon need not be convex. Whether the ray starts inside the
+// polygon is determined by the [NonZero] fill rule.
+func (r Ray) CastPolygon(p Polygon) (Hit, bool) {
+	if !r.valid() {
+		return Hit{}, false
+	}
+	if len(p) > 2 && p.Contains(r.Origin, NonZero) {
+		return r.inside()
+	}
+	var best Hit
+	found := false
+	for e := range p.Edges() {
+		if h, ok := r.CastSegment(e); ok && (!found || h.Dist < best.Dist) {
+			best, found = h, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,296 @@
+package geom
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Simplify returns a simplified copy of the polyline through pts, using the
+// Ramer-Douglas-Peucker algorithm. It keeps as few of the points as it can,
+// such that no point is further than tolerance from the simplified
+// polyline. The first and last points are always kept.
+//
+// If preserveTopology is true, points are kept as necessary to prevent the
+// simplified polyline from crossing itself, provided the original doesn't.
+func Simplify(pts []Point, tolerance float64, preserveTopology bool) []Point {
+	if len(pts) < 3 {
+		return slices.Clone(pts)
+	}
+	keep := make([]bool, len(pts))
+	keep[0], keep[len(pts)-1] = true, true
+	rdp(pts, 0, len(pts)-1, tolerance, keep)
+	if preserveTopology {
+		untangle(pts, keep, false)
+	}
+	return kept(pts, keep)
+}
+
+// Simplify returns a simplified copy of the polygon, using the
+// Ramer-Douglas-Peucker algorithm. See [Simplify].
+//
+// Only the vertex furthest from the first is always kept, along with the
+// first itself. The result has at least three vertices unless the polygon
+// had fewer.
+func (p Polygon) Simplify(tolerance float64, preserveTopology bool) Polygon {
+	if len(p) < 4 {
+		return slices.Clone(p)
+	}
+	// Split the polygon into two polylines between the first vertex and
+	// the one furthest from it, and simplify each.
+	far := 0
+	for i, pt := range p {
+		if Vec(p[0], pt).Length2() > Vec(p[0], p[far]).Length2() {
+			far = i
+		}
+	}
+	if far == 0 {
+		// Every vertex is the same point.
+		return slices.Clone(p[:3])
+	}
+	ring := append(slices.Clone(p), p[0])
+	keep := make([]bool, len(ring))
+	keep[0], keep[far], keep[len(p)] = true, true, true
+	rdp(ring, 0, far, tolerance, keep)
+	rdp(ring, far, len(p), tolerance, keep)
+	keep = keep[:len(p)]
+	if count(keep) < 3 {
+		// Keep the vertex furthest from the line between the other two.
+		best, bestD := -1, -1.0
+		for i, pt := range p {
+			if d := Seg(p[0], p[far]).Distance(pt); !keep[i] && d > bestD {
+				best, bestD = i, d
+			}
+		}
+		keep[best] = true
+	}
+	if preserveTopology {
+		untangle(p, keep, true)
+	}
+	return Polygon(kept(p, keep))
+}
+
+// rdp marks the points between i and j that must be kept.
+func rdp(pts []Point, i, j int, tolerance float64, keep []bool) {
+	if j-i < 2 {
+		return
+	}
+	k, d := farthest(pts, i, j)
+	if d <= tolerance {
+		return
+	}
+	keep[k] = true
+	rdp(pts, i, k, tolerance, keep)
+	rdp(pts, k, j, tolerance, keep)
+}
+
+// farthest returns the point strictly between i and j that's furthest
+// from the segment between them, and its distance.
+func farthest(pts []Point, i, j int) (int, float64) {
+	s := Seg(pts[i], pts[j])
+	best, bestD := i+1, -1.0
+	for k := i + 1; k < j; k++ {
+		if d := s.Distance(pts[k]); d > bestD {
+			best, bestD = k, d
+		}
+	}
+	return best, bestD
+}
+
+// untangle keeps more of the points until the simplified polyline,
+// or polygon if closed, no longer crosses itself.
+func untangle(pts []Point, keep []bool, closed bool) {
+	for {
+		idx := make([]int, 0, len(pts))
+		for i, k := range keep {
+			if k {
+				idx = append(idx, i)
+			}
+		}
+		n := len(idx) - 1
+		if closed {
+			idx = append(idx, len(pts))
+			n++
+		}
+		at := func(i int) Point { return pts[i%len(pts)] }
+		changed := false
+		for a := range n {
+			for b := a + 1; b < n; b++ {
+				sa := Seg(at(idx[a]), at(idx[a+1]))
+				sb := Seg(at(idx[b]), at(idx[b+1]))
+				if !crossesAway(sa, sb) {
+					continue
+				}
+				// Restore the furthest point of each span that's been
+				// simplified away.
+				for _, s := range [2]int{a, b} {
+					i, j := idx[s], idx[s+1]
+					if j-i < 2 {
+						continue
+					}
+					ring := pts
+					if closed && j == len(pts) {
+						ring = append(slices.Clone(pts), pts[0])
+					}
+					k, _ := farthest(ring, i, j)
+					keep[k] = true
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+}
+
+// crossesAway returns true if s0 and s1 meet anywhere other than at a
+// single end point they share.
+func crossesAway(s0, s1 Segment) bool {
+	x, ok := s0.Intersection(s1)
+	if !ok {
+		return false
+	}
+	if !x.ZeroLength() {
+		return true
+	}
+	p := x.Start
+	shared := (p == s0.Start || p == s0.End) && (p == s1.Start || p == s1.End)
+	return !shared
+}
+
+func count(keep []bool) int {
+	n := 0
+	for _, k := range keep {
+		if k {
+			n++
+		}
+	}
+	return n
+}
+
+func kept(pts []Point, keep []bool) []Point {
+	var out []Point
+	for i, k := range keep {
+		if k {
+			out = append(out, pts[i])
+		}
+	}
+	return out
+}
+
+// SimplifyCount returns a simplified copy of the polyline through pts with
+// at most n points, using the Visvalingam-Whyatt algorithm. It repeatedly
+// removes the point that forms the smallest triangle with its neighbors.
+// The first and last points are always kept.
+//
+// If preserveTopology is true, points whose removal would make the
+// polyline cross itself are kept, even if that leaves more than n points.
+func SimplifyCount(pts []Point, n int, preserveTopology bool) []Point {
+	return visvalingam(pts, max(n, 2), false, preserveTopology)
+}
+
+// SimplifyCount returns a simplified copy of the polygon with at most n
+// vertices, and no fewer than three, using the Visvalingam-Whyatt
+// algorithm. See [SimplifyCount].
+func (p Polygon) SimplifyCount(n int, preserveTopology bool) Polygon {
+	return Polygon(visvalingam(p, max(n, 3), true, preserveTopology))
+}
+
+func visvalingam(pts []Point, n int, closed, preserveTopology bool) []Point {
+	if len(pts) <= n {
+		return slices.Clone(pts)
+	}
+	prev := make([]int, len(pts))
+	next := make([]int, len(pts))
+	for i := range pts {
+		prev[i], next[i] = i-1, i+1
+	}
+	if closed {
+		prev[0], next[len(pts)-1] = len(pts)-1, 0
+	}
+	removable := func(i int) bool {
+		return prev[i] >= 0 && next[i] < len(pts)
+	}
+	area := func(i int) float64 {
+		return Poly(pts[prev[i]], pts[i], pts[next[i]]).Area()
+	}
+	h := &vwHeap{}
+	version := make([]int, len(pts))
+	for i := range pts {
+		if removable(i) {
+			heap.Push(h, vwEntry{i, area(i), 0})
+		}
+	}
+	removed := make([]bool, len(pts))
+	// blocked holds points that couldn't be removed without crossing, to
+	// be reconsidered when their neighbors change.
+	var blocked []vwEntry
+	remaining := len(pts)
+	for remaining > n && h.Len() > 0 {
+		e := heap.Pop(h).(vwEntry)
+		if removed[e.i] || e.version != version[e.i] {
+			continue
+		}
+		a, c := prev[e.i], next[e.i]
+		if preserveTopology && vwCrosses(pts, next, removed, a, e.i, c) {
+			blocked = append(blocked, e)
+			continue
+		}
+		removed[e.i] = true
+		remaining--
+		next[a], prev[c] = c, a
+		for _, j := range [2]int{a, c} {
+			if removable(j) {
+				version[j]++
+				heap.Push(h, vwEntry{j, area(j), version[j]})
+			}
+		}
+		// Removing a point may have unblocked others.
+		for _, b := range blocked {
+			if !removed[b.i] && b.version == version[b.i] {
+				heap.Push(h, b)
+			}
+		}
+		blocked = blocked[:0]
+	}
+	keep := make([]bool, len(pts))
+	for i, r := range removed {
+		keep[i] = !r
+	}
+	return kept(pts, keep)
+}
+
+// vwCrosses returns true if replacing the segments from a to b to c with
+// one from a to c would cross another segment of the polyline.
+func vwCrosses(pts []Point, next []int, removed []bool, a, b, c int) bool {
+	s := Seg(pts[a], pts[c])
+	for i := range pts {
+		j := next[i]
+		if removed[i] || j < 0 || j >= len(pts) || i == b || j == b {
+			continue
+		}
+		if crossesAway(s, Seg(pts[i], pts[j])) {
+			return true
+		}
+	}
+	return false
+}
+
+type vwEntry struct {
+	i       int
+	area    float64
+	version int
+}
+
+type vwHeap []vwEntry
+
+func (h vwHeap) Len() int           { return len(h) }
+func (h vwHeap) Less(i, j int) bool { return h[i].area < h[j].area }
+func (h vwHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *vwHeap) Push(x any)        { *h = append(*h, x.(vwEntry)) }
+func (h *vwHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
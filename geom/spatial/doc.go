@@ -0,0 +1,20 @@
+/*
+Package spatial provides broad-phase spatial indexes over AABBs.
+
+Testing every pair of objects in a scene for overlap takes time that
+grows with the square of the number of objects. A spatial index keeps
+track of where each object's [geom.AABB] lies, so that it can quickly
+find the objects near a region, a point, or a ray, as well as every pair
+of objects whose AABBs intersect. Exact tests on the objects themselves
+can then be limited to those candidates.
+
+Every index implements the [Index] interface. The package provides:
+
+  - [Hash], a uniform grid of cells, which works best when objects are
+    all of a similar size.
+  - [Quadtree], a loose quadtree over a fixed region, which copes well
+    with objects of very different sizes.
+  - [Tree], a dynamic bounding volume hierarchy, which suits worlds
+    where most objects are still.
+*/
+package spatial
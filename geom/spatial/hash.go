@@ -0,0 +1,383 @@
+package spatial
+
+import (
+	"iter"
+	"math"
+
+	"github.com/mknyszek/2d/geom"
+)
+
+// Hash is an [Index] that divides space into a uniform grid of square
+// cells, and keeps a list of the items whose bounds overlap each cell.
+// Only cells that hold items take up memory.
+//
+// Cells should be a little larger than a typical item. Much smaller, and
+// each item is listed in many cells; much larger, and each cell lists
+// too many items. Items that would be listed in more than a few hundred
+// cells are instead kept in a separate list, which every query checks
+// in full.
+type Hash[T any] struct {
+	store[T, cellRange]
+
+	size  float64
+	cells map[cell][]Handle
+	large []Handle
+
+	// extent covers every cell that holds items.
+	extent cellRange
+}
+
+var _ Index[int] = &Hash[int]{}
+
+type cell struct {
+	x, y int
+}
+
+// cellRange is an inclusive range of cells.
+type cellRange struct {
+	min, max cell
+}
+
+// maxCell limits cell coordinates, so that they fit in 32 bits however
+// far away an AABB lies, and can be stepped past without overflowing.
+const maxCell = math.MaxInt32 - 1
+
+// maxItemCells is the most cells an item may be listed in. Larger items
+// are kept in Hash.large instead.
+const maxItemCells = 256
+
+// NewHash returns a new empty Hash whose cells have sides of length
+// cellSize.
+func NewHash[T any](cellSize float64) *Hash[T] {
+	if !(cellSize > 0) {
+		panic("spatial: cell size must be positive")
+	}
+	return &Hash[T]{size: cellSize, cells: make(map[cell][]Handle)}
+}
+
+func (h *Hash[T]) cellOf(p geom.Point) cell {
+	coord := func(v float64) int {
+		return int(max(-maxCell, min(maxCell, math.Floor(v/h.size))))
+	}
+	return cell{coord(p.X), coord(p.Y)}
+}
+
+func (h *Hash[T]) rangeOf(b geom.AABB) cellRange {
+	return cellRange{h.cellOf(b.Min), h.cellOf(b.Max)}
+}
+
+// cells returns the number of cells in r.
+func (r cellRange) cells() float64 {
+	return (float64(r.max.x) - float64(r.min.x) + 1) * (float64(r.max.y) - float64(r.min.y) + 1)
+}
+
+// large returns true if an item covering r belongs in Hash.large.
+func (r cellRange) large() bool {
+	return r.cells() > maxItemCells
+}
+
+func (r cellRange) contains(c cell) bool {
+	return c.x >= r.min.x && c.x <= r.max.x && c.y >= r.min.y && c.y <= r.max.y
+}
+
+// onEdge returns true if c lies on the edge of r.
+func (r cellRange) onEdge(c cell) bool {
+	return c.x == r.min.x || c.x == r.max.x || c.y == r.min.y || c.y == r.max.y
+}
+
+// first returns the lowest cell in both r and o, which must overlap.
+// It's used to report something found in several cells only once.
+func (r cellRange) first(o cellRange) cell {
+	return cell{max(r.min.x, o.min.x), max(r.min.y, o.min.y)}
+}
+
+func (r cellRange) union(o cellRange) cellRange {
+	return cellRange{
+		cell{min(r.min.x, o.min.x), min(r.min.y, o.min.y)},
+		cell{max(r.max.x, o.max.x), max(r.max.y, o.max.y)},
+	}
+}
+
+// Insert adds value to the index with the given bounds, and returns
+// a handle for it.
+func (h *Hash[T]) Insert(bounds geom.AABB, value T) Handle {
+	id := h.add(bounds, value)
+	r := h.rangeOf(bounds)
+	h.items[id].x = r
+	h.link(id, r)
+	return id
+}
+
+// Update changes the bounds of the item with handle id.
+func (h *Hash[T]) Update(id Handle, bounds geom.AABB) {
+	it := h.get(id)
+	it.bounds = bounds
+	if r := h.rangeOf(bounds); r != it.x {
+		h.unlink(id, it.x)
+		it.x = r
+		h.link(id, r)
+	}
+}
+
+// Remove removes the item with handle id from the index.
+func (h *Hash[T]) Remove(id Handle) {
+	h.unlink(id, h.get(id).x)
+	h.remove(id)
+}
+
+func (h *Hash[T]) link(id Handle, r cellRange) {
+	if r.large() {
+		h.large = append(h.large, id)
+		return
+	}
+	if len(h.cells) == 0 {
+		h.extent = r
+	} else {
+		h.extent = h.extent.union(r)
+	}
+	for x := r.min.x; x <= r.max.x; x++ {
+		for y := r.min.y; y <= r.max.y; y++ {
+			h.cells[cell{x, y}] = append(h.cells[cell{x, y}], id)
+		}
+	}
+}
+
+func (h *Hash[T]) unlink(id Handle, r cellRange) {
+	if r.large() {
+		h.large = removeHandle(h.large, id)
+		return
+	}
+	shrunk := false
+	for x := r.min.x; x <= r.max.x; x++ {
+		for y := r.min.y; y <= r.max.y; y++ {
+			c := cell{x, y}
+			ids := removeHandle(h.cells[c], id)
+			if len(ids) > 0 {
+				h.cells[c] = ids
+				continue
+			}
+			delete(h.cells, c)
+			shrunk = shrunk || h.extent.onEdge(c)
+		}
+	}
+	if shrunk {
+		// An emptied cell was on the edge of the extent, which may now
+		// be smaller.
+		first := true
+		for c := range h.cells {
+			if first {
+				h.extent, first = cellRange{c, c}, false
+			}
+			h.extent = h.extent.union(cellRange{c, c})
+		}
+	}
+}
+
+// removeHandle removes id from ids, which need not stay in order.
+func removeHandle(ids []Handle, id Handle) []Handle {
+	for i, other := range ids {
+		if other == id {
+			ids[i] = ids[len(ids)-1]
+			return ids[:len(ids)-1]
+		}
+	}
+	return ids
+}
+
+// visit calls f for each occupied cell in r, until f returns false.
+func (h *Hash[T]) visit(r cellRange, f func(c cell, ids []Handle) bool) bool {
+	if r.cells() > float64(len(h.cells)) {
+		// There are fewer occupied cells than cells in the range.
+		for c, ids := range h.cells {
+			if r.contains(c) && !f(c, ids) {
+				return false
+			}
+		}
+		return true
+	}
+	for x := r.min.x; x <= r.max.x; x++ {
+		for y := r.min.y; y <= r.max.y; y++ {
+			c := cell{x, y}
+			if ids, ok := h.cells[c]; ok && !f(c, ids) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Query returns an iterator over the items whose bounds overlap region.
+func (h *Hash[T]) Query(region geom.AABB) iter.Seq2[Handle, T] {
+	return func(yield func(Handle, T) bool) {
+		if !h.queryCells(region, yield) {
+			return
+		}
+		for _, id := range h.large {
+			it := &h.items[id]
+			if it.bounds.Intersects(region) && !yield(id, it.value) {
+				return
+			}
+		}
+	}
+}
+
+// queryCells yields the items listed in cells whose bounds overlap
+// region. It returns false if yield did.
+func (h *Hash[T]) queryCells(region geom.AABB, yield func(Handle, T) bool) bool {
+	r := h.rangeOf(region)
+	return h.visit(r, func(c cell, ids []Handle) bool {
+		for _, id := range ids {
+			it := &h.items[id]
+			if c != r.first(it.x) || !it.bounds.Intersects(region) {
+				continue
+			}
+			if !yield(id, it.value) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// QueryPoint returns an iterator over the items whose bounds contain p.
+func (h *Hash[T]) QueryPoint(p geom.Point) iter.Seq2[Handle, T] {
+	return func(yield func(Handle, T) bool) {
+		for _, ids := range [2][]Handle{h.cells[h.cellOf(p)], h.large} {
+			for _, id := range ids {
+				it := &h.items[id]
+				if it.bounds.Contains(p) && !yield(id, it.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Raycast returns an iterator over the items whose bounds are hit by r,
+// along with where each is hit, nearest first.
+//
+// It walks the cells that r passes through in order, so it stops early
+// if iteration stops, but an unbounded ray that misses everything nearby
+// visits every cell between its origin and the furthest item.
+func (h *Hash[T]) Raycast(r geom.Ray) iter.Seq2[Handle, geom.Hit] {
+	return func(yield func(Handle, geom.Hit) bool) {
+		var q rayQueue
+		for _, id := range h.large {
+			if hit, ok := r.CastAABB(h.items[id].bounds); ok {
+				q.pushHit(id, hit)
+			}
+		}
+		if len(h.cells) > 0 && !h.raycastCells(r, &q, yield) {
+			return
+		}
+		for q.Len() > 0 {
+			e := q.pop()
+			if !yield(e.h, e.hit) {
+				return
+			}
+		}
+	}
+}
+
+// raycastCells yields the hits in q, along with those on items listed in
+// the cells r passes through, as long as they're nearer than any hit yet
+// to be found. It returns false if yield did.
+func (h *Hash[T]) raycastCells(r geom.Ray, q *rayQueue, yield func(Handle, geom.Hit) bool) bool {
+	ext := h.extent
+	bounds := geom.AABB{
+		Min: geom.Pt(float64(ext.min.x)*h.size, float64(ext.min.y)*h.size),
+		Max: geom.Pt(float64(ext.max.x+1)*h.size, float64(ext.max.y+1)*h.size),
+	}
+	enter, ok := r.CastAABB(bounds)
+	if !ok {
+		return true
+	}
+	limit := r.Max
+	if limit == 0 {
+		limit = math.Inf(1)
+	}
+
+	// Step from cell to cell, tracking the distance along the ray at
+	// which it crosses the next vertical and horizontal cell borders.
+	t := enter.Dist
+	p := r.At(t)
+	c := h.cellOf(p)
+	c.x = max(ext.min.x, min(ext.max.x, c.x))
+	c.y = max(ext.min.y, min(ext.max.y, c.y))
+	axis := func(pos, dir float64, c int) (step int, next, delta float64) {
+		switch {
+		case dir > 0:
+			return 1, t + (float64(c+1)*h.size-pos)/dir, h.size / dir
+		case dir < 0:
+			return -1, t + (float64(c)*h.size-pos)/dir, -h.size / dir
+		}
+		return 0, math.Inf(1), 0
+	}
+	stepX, nextX, deltaX := axis(p.X, r.Dir.X, c.x)
+	stepY, nextY, deltaY := axis(p.Y, r.Dir.Y, c.y)
+
+	seen := make(map[Handle]bool)
+	for ext.contains(c) {
+		for _, id := range h.cells[c] {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if hit, ok := r.CastAABB(h.items[id].bounds); ok {
+				q.pushHit(id, hit)
+			}
+		}
+		// Everything hit before the ray leaves this cell has been
+		// found by now.
+		exit := min(nextX, nextY)
+		for q.Len() > 0 && q.peek() <= exit {
+			e := q.pop()
+			if !yield(e.h, e.hit) {
+				return false
+			}
+		}
+		if exit > limit {
+			break
+		}
+		if nextX < nextY {
+			c.x += stepX
+			nextX += deltaX
+		} else {
+			c.y += stepY
+			nextY += deltaY
+		}
+	}
+	return true
+}
+
+// Pairs returns an iterator over every pair of items whose bounds
+// overlap.
+func (h *Hash[T]) Pairs() iter.Seq2[Handle, Handle] {
+	return func(yield func(Handle, Handle) bool) {
+		for c, ids := range h.cells {
+			for i, a := range ids {
+				ia := &h.items[a]
+				for _, b := range ids[i+1:] {
+					ib := &h.items[b]
+					if c != ia.x.first(ib.x) || !ia.bounds.Intersects(ib.bounds) {
+						continue
+					}
+					if !yield(a, b) {
+						return
+					}
+				}
+			}
+		}
+		for i, a := range h.large {
+			ia := &h.items[a]
+			if !h.queryCells(ia.bounds, func(b Handle, _ T) bool { return yield(a, b) }) {
+				return
+			}
+			for _, b := range h.large[i+1:] {
+				if ia.bounds.Intersects(h.items[b].bounds) && !yield(a, b) {
+					return
+				}
+			}
+		}
+	}
+}
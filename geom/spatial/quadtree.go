@@ -0,0 +1,285 @@
+package spatial
+
+import (
+	"iter"
+
+	"github.com/mknyszek/2d/geom"
+)
+
+// Quadtree is an [Index] that recursively divides a region into quarters.
+//
+// It's a loose quadtree: each node accepts items whose centers lie in its
+// quarter of the region and which extend no further than half the
+// quarter's size beyond it. Each item is kept in the deepest node that
+// accepts it, so no item is ever split between nodes, and large items
+// stay near the root.
+//
+// Items whose centers lie outside the region are kept at the root,
+// which makes queries near them slower.
+type Quadtree[T any] struct {
+	store[T, qslot]
+
+	bounds    geom.AABB
+	maxDepth  int
+	nodes     []qnode
+	freeNodes []int
+}
+
+var _ Index[int] = &Quadtree[int]{}
+
+// qslot records where an item is kept in a Quadtree.
+type qslot struct {
+	node, index int
+}
+
+type qnode struct {
+	bounds   geom.AABB // the node's quarter of its parent's bounds
+	loose    geom.AABB // bounds grown by half its size on every side
+	parent   int
+	children [4]int // 0 if absent
+	items    []Handle
+	count    int // number of items in the subtree
+}
+
+// NewQuadtree returns a new empty Quadtree that divides bounds into
+// quarters, to no more than maxDepth levels below the root.
+func NewQuadtree[T any](bounds geom.AABB, maxDepth int) *Quadtree[T] {
+	q := &Quadtree[T]{bounds: bounds, maxDepth: maxDepth}
+	q.nodes = append(q.nodes, qnode{bounds: bounds, loose: bounds, parent: -1})
+	return q
+}
+
+// overlaps returns true if the loose bounds of node n intersect region.
+// The root's are unlimited, since it holds items from outside its bounds.
+func (q *Quadtree[T]) overlaps(n int, region geom.AABB) bool {
+	return n == 0 || q.nodes[n].loose.Intersects(region)
+}
+
+// place returns the node that should hold an item with the given bounds,
+// creating it if needed.
+func (q *Quadtree[T]) place(bounds geom.AABB) int {
+	c := bounds.Center()
+	if !q.bounds.Contains(c) {
+		return 0
+	}
+	n := 0
+	for range q.maxDepth {
+		// The item fits in a child if it's no larger than the child,
+		// given that its center lies within the child.
+		nb := q.nodes[n].bounds
+		if bounds.Dx() > nb.Dx()/2 || bounds.Dy() > nb.Dy()/2 {
+			break
+		}
+		mid := nb.Center()
+		k := 0
+		if c.X >= mid.X {
+			k |= 1
+		}
+		if c.Y >= mid.Y {
+			k |= 2
+		}
+		n = q.child(n, k)
+	}
+	return n
+}
+
+// child returns the k'th child of node n, creating it if needed. Bit 0 of
+// k selects the right half of n, and bit 1 the upper half.
+func (q *Quadtree[T]) child(n, k int) int {
+	if c := q.nodes[n].children[k]; c != 0 {
+		return c
+	}
+	nb := q.nodes[n].bounds
+	mid := nb.Center()
+	b := geom.AABB{Min: nb.Min, Max: mid}
+	if k&1 != 0 {
+		b.Min.X, b.Max.X = mid.X, nb.Max.X
+	}
+	if k&2 != 0 {
+		b.Min.Y, b.Max.Y = mid.Y, nb.Max.Y
+	}
+	half := geom.Vector{X: b.Dx() / 2, Y: b.Dy() / 2}
+	node := qnode{
+		bounds: b,
+		loose:  geom.AABB{Min: b.Min.Add(half.Neg()), Max: b.Max.Add(half)},
+		parent: n,
+	}
+	var c int
+	if len(q.freeNodes) > 0 {
+		c = q.freeNodes[len(q.freeNodes)-1]
+		q.freeNodes = q.freeNodes[:len(q.freeNodes)-1]
+		q.nodes[c] = node
+	} else {
+		c = len(q.nodes)
+		q.nodes = append(q.nodes, node)
+	}
+	q.nodes[n].children[k] = c
+	return c
+}
+
+// Insert adds value to the index with the given bounds, and returns
+// a handle for it.
+func (q *Quadtree[T]) Insert(bounds geom.AABB, value T) Handle {
+	id := q.add(bounds, value)
+	q.link(id, q.place(bounds))
+	return id
+}
+
+// Update changes the bounds of the item with handle id.
+func (q *Quadtree[T]) Update(id Handle, bounds geom.AABB) {
+	it := q.get(id)
+	it.bounds = bounds
+	if n := q.place(bounds); n != it.x.node {
+		// Unlinking may release n if it's new, so place the item again.
+		q.unlink(id)
+		q.link(id, q.place(bounds))
+	}
+}
+
+// Remove removes the item with handle id from the index.
+func (q *Quadtree[T]) Remove(id Handle) {
+	q.get(id)
+	q.unlink(id)
+	q.remove(id)
+}
+
+func (q *Quadtree[T]) link(id Handle, n int) {
+	node := &q.nodes[n]
+	q.items[id].x = qslot{n, len(node.items)}
+	node.items = append(node.items, id)
+	for ; n >= 0; n = q.nodes[n].parent {
+		q.nodes[n].count++
+	}
+}
+
+func (q *Quadtree[T]) unlink(id Handle) {
+	s := q.items[id].x
+	node := &q.nodes[s.node]
+	last := node.items[len(node.items)-1]
+	node.items[s.index] = last
+	q.items[last].x.index = s.index
+	node.items = node.items[:len(node.items)-1]
+
+	// Release the largest subtree that's now empty.
+	empty := -1
+	for n := s.node; n >= 0; n = q.nodes[n].parent {
+		if q.nodes[n].count--; q.nodes[n].count == 0 && n != 0 {
+			empty = n
+		}
+	}
+	if empty >= 0 {
+		p := &q.nodes[q.nodes[empty].parent]
+		for k, c := range p.children {
+			if c == empty {
+				p.children[k] = 0
+			}
+		}
+		q.release(empty)
+	}
+}
+
+func (q *Quadtree[T]) release(n int) {
+	for _, c := range q.nodes[n].children {
+		if c != 0 {
+			q.release(c)
+		}
+	}
+	q.nodes[n] = qnode{}
+	q.freeNodes = append(q.freeNodes, n)
+}
+
+// Query returns an iterator over the items whose bounds overlap region.
+func (q *Quadtree[T]) Query(region geom.AABB) iter.Seq2[Handle, T] {
+	return func(yield func(Handle, T) bool) {
+		stack := []int{0}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			node := &q.nodes[n]
+			for _, id := range node.items {
+				it := &q.items[id]
+				if it.bounds.Intersects(region) && !yield(id, it.value) {
+					return
+				}
+			}
+			for _, c := range node.children {
+				if c != 0 && q.overlaps(c, region) {
+					stack = append(stack, c)
+				}
+			}
+		}
+	}
+}
+
+// QueryPoint returns an iterator over the items whose bounds contain p.
+func (q *Quadtree[T]) QueryPoint(p geom.Point) iter.Seq2[Handle, T] {
+	return func(yield func(Handle, T) bool) {
+		stack := []int{0}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			node := &q.nodes[n]
+			for _, id := range node.items {
+				it := &q.items[id]
+				if it.bounds.Contains(p) && !yield(id, it.value) {
+					return
+				}
+			}
+			for _, c := range node.children {
+				if c != 0 && q.nodes[c].loose.Contains(p) {
+					stack = append(stack, c)
+				}
+			}
+		}
+	}
+}
+
+// Raycast returns an iterator over the items whose bounds are hit by r,
+// along with where each is hit, nearest first.
+func (q *Quadtree[T]) Raycast(r geom.Ray) iter.Seq2[Handle, geom.Hit] {
+	return func(yield func(Handle, geom.Hit) bool) {
+		var rq rayQueue
+		rq.pushNode(0, 0)
+		for rq.Len() > 0 {
+			e := rq.pop()
+			if e.node < 0 {
+				if !yield(e.h, e.hit) {
+					return
+				}
+				continue
+			}
+			node := &q.nodes[e.node]
+			for _, id := range node.items {
+				if hit, ok := r.CastAABB(q.items[id].bounds); ok {
+					rq.pushHit(id, hit)
+				}
+			}
+			for _, c := range node.children {
+				if c == 0 {
+					continue
+				}
+				if hit, ok := r.CastAABB(q.nodes[c].loose); ok {
+					rq.pushNode(c, hit.Dist)
+				}
+			}
+		}
+	}
+}
+
+// Pairs returns an iterator over every pair of items whose bounds
+// overlap.
+func (q *Quadtree[T]) Pairs() iter.Seq2[Handle, Handle] {
+	return func(yield func(Handle, Handle) bool) {
+		for i := range q.items {
+			a := Handle(i)
+			if !q.items[a].live {
+				continue
+			}
+			for b := range q.Query(q.items[a].bounds) {
+				if b > a && !yield(a, b) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package spatial
+
+import (
+	"container/heap"
+	"iter"
+
+	"github.com/mknyszek/2d/geom"
+)
+
+// Handle identifies an item in an [Index].
+//
+// Handles are only meaningful to the index that returned them, and the
+// handle of a removed item may be reused by a later insertion.
+type Handle int
+
+// Index is a spatial index over values of type T, each of which is
+// associated with an AABB.
+//
+// Two AABBs overlap if they intersect according to [geom.AABB.Intersects],
+// so AABBs that only touch do not overlap. An index must not be modified
+// while iterating over one of its queries.
+type Index[T any] interface {
+	// Insert adds value to the index with the given bounds, and returns
+	// a handle for it.
+	Insert(bounds geom.AABB, value T) Handle
+
+	// Update changes the bounds of the item with handle h.
+	Update(h Handle, bounds geom.AABB)
+
+	// Remove removes the item with handle h from the index.
+	Remove(h Handle)
+
+	// Bounds returns the bounds of the item with handle h.
+	Bounds(h Handle) geom.AABB
+
+	// Value returns the value of the item with handle h.
+	Value(h Handle) T
+
+	// Len returns the number of items in the index.
+	Len() int
+
+	// Query returns an iterator over the items whose bounds overlap
+	// region.
+	Query(region geom.AABB) iter.Seq2[Handle, T]
+
+	// QueryPoint returns an iterator over the items whose bounds contain
+	// p. See [geom.AABB.Contains].
+	QueryPoint(p geom.Point) iter.Seq2[Handle, T]
+
+	// Raycast returns an iterator over the items whose bounds are hit by
+	// r, along with where each is hit, nearest first.
+	Raycast(r geom.Ray) iter.Seq2[Handle, geom.Hit]
+
+	// Pairs returns an iterator over every pair of items whose bounds
+	// overlap. Each pair is produced once, in no particular order.
+	Pairs() iter.Seq2[Handle, Handle]
+}
+
+// item is an item in an index, along with whatever data the index
+// needs to find it again.
+type item[T, X any] struct {
+	bounds geom.AABB
+	value  T
+	live   bool
+	x      X
+}
+
+// store holds the items of an index, and hands out their handles.
+type store[T, X any] struct {
+	items []item[T, X]
+	free  []Handle
+}
+
+func (s *store[T, X]) add(bounds geom.AABB, value T) Handle {
+	it := item[T, X]{bounds: bounds, value: value, live: true}
+	if n := len(s.free); n > 0 {
+		h := s.free[n-1]
+		s.free = s.free[:n-1]
+		s.items[h] = it
+		return h
+	}
+	s.items = append(s.items, it)
+	return Handle(len(s.items) - 1)
+}
+
+func (s *store[T, X]) get(h Handle) *item[T, X] {
+	if h < 0 || int(h) >= len(s.items) || !s.items[h].live {
+		panic("spatial: invalid handle")
+	}
+	return &s.items[h]
+}
+
+func (s *store[T, X]) remove(h Handle) {
+	s.items[h] = item[T, X]{}
+	s.free = append(s.free, h)
+}
+
+// Bounds returns the bounds of the item with handle h.
+func (s *store[T, X]) Bounds(h Handle) geom.AABB {
+	return s.get(h).bounds
+}
+
+// Value returns the value of the item with handle h.
+func (s *store[T, X]) Value(h Handle) T {
+	return s.get(h).value
+}
+
+// Len returns the number of items in the index.
+func (s *store[T, X]) Len() int {
+	return len(s.items) - len(s.free)
+}
+
+// rayQueue orders the work of a raycast by distance along the ray.
+// Each entry is either a hit on an item, or a part of an index that
+// has yet to be searched, keyed by the distance at which the ray enters
+// it.
+type rayQueue []rayEntry
+
+type rayEntry struct {
+	hit  geom.Hit
+	h    Handle
+	node int // -1 for hits
+}
+
+func (q rayQueue) Len() int           { return len(q) }
+func (q rayQueue) Less(i, j int) bool { return q[i].hit.Dist < q[j].hit.Dist }
+func (q rayQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *rayQueue) Push(x any)        { *q = append(*q, x.(rayEntry)) }
+func (q *rayQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
+
+func (q *rayQueue) pushHit(h Handle, hit geom.Hit) {
+	heap.Push(q, rayEntry{hit: hit, h: h, node: -1})
+}
+
+func (q *rayQueue) pushNode(node int, dist float64) {
+	heap.Push(q, rayEntry{hit: geom.Hit{Dist: dist}, node: node})
+}
+
+func (q *rayQueue) pop() rayEntry {
+	return heap.Pop(q).(rayEntry)
+}
+
+// peek returns the distance of the nearest entry.
+func (q rayQueue) peek() float64 {
+	return q[0].hit.Dist
+}
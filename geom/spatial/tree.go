@@ -0,0 +1,401 @@
+package spatial
+
+import (
+	"iter"
+
+	"github.com/mknyszek/2d/geom"
+)
+
+// Tree is an [Index] that keeps items in a dynamic bounding volume
+// hierarchy: a binary tree whose leaves are items, and where each node's
+// AABB encloses those of its children. The tree is rebalanced as items
+// are inserted and removed, so its depth stays logarithmic in the number
+// of items.
+//
+// Each leaf's AABB is the item's bounds grown by a margin, so that an
+// item that moves a little needn't be moved within the tree. This makes
+// Tree well suited to worlds where most items are still, or move slowly.
+type Tree[T any] struct {
+	store[T, int]
+
+	margin float64
+	nodes  []tnode
+	free   []int
+	root   int
+}
+
+var _ Index[int] = &Tree[int]{}
+
+type tnode struct {
+	bounds      geom.AABB
+	parent      int
+	left, right int // -1 for leaves
+	height      int // 0 for leaves
+	item        Handle
+}
+
+func (n *tnode) leaf() bool {
+	return n.left < 0
+}
+
+// predict is how many times an item's displacement its leaf is extended
+// by when it moves. See [Tree.Move].
+const predict = 2
+
+// NewTree returns a new empty Tree, which grows the bounds of each item
+// by margin on every side.
+func NewTree[T any](margin float64) *Tree[T] {
+	return &Tree[T]{margin: margin, root: -1}
+}
+
+// fatten returns bounds grown by the tree's margin, and extended in the
+// direction of d.
+func (t *Tree[T]) fatten(bounds geom.AABB, d geom.Vector) geom.AABB {
+	m := geom.Vector{X: t.margin, Y: t.margin}
+	fat := geom.AABB{Min: bounds.Min.Add(m.Neg()), Max: bounds.Max.Add(m)}
+	return fat.Union(fat.Translate(d.Scale(predict)))
+}
+
+// encloses returns true if b lies within a.
+func encloses(a, b geom.AABB) bool {
+	return a.Contains(b.Min) && a.Contains(b.Max)
+}
+
+func perimeter(a geom.AABB) float64 {
+	return 2 * (a.Dx() + a.Dy())
+}
+
+func (t *Tree[T]) alloc() int {
+	n := tnode{parent: -1, left: -1, right: -1}
+	if k := len(t.free); k > 0 {
+		i := t.free[k-1]
+		t.free = t.free[:k-1]
+		t.nodes[i] = n
+		return i
+	}
+	t.nodes = append(t.nodes, n)
+	return len(t.nodes) - 1
+}
+
+func (t *Tree[T]) release(i int) {
+	t.free = append(t.free, i)
+}
+
+// Insert adds value to the index with the given bounds, and returns
+// a handle for it.
+func (t *Tree[T]) Insert(bounds geom.AABB, value T) Handle {
+	id := t.add(bounds, value)
+	leaf := t.alloc()
+	t.nodes[leaf].bounds = t.fatten(bounds, geom.Zero)
+	t.nodes[leaf].item = id
+	t.items[id].x = leaf
+	t.insertLeaf(leaf)
+	return id
+}
+
+// Update changes the bounds of the item with handle id.
+// It's equivalent to calling [Tree.Move] with no displacement.
+func (t *Tree[T]) Update(id Handle, bounds geom.AABB) {
+	t.Move(id, bounds, geom.Zero)
+}
+
+// Move changes the bounds of the item with handle id, which moved by d
+// since its bounds were last set.
+//
+// If the new bounds leave the item's leaf, the leaf is moved within the
+// tree, and extended in the direction of d in anticipation of further
+// movement. The leaf is also shrunk if it has grown much larger than
+// the item.
+func (t *Tree[T]) Move(id Handle, bounds geom.AABB, d geom.Vector) {
+	it := t.get(id)
+	it.bounds = bounds
+	leaf := it.x
+	fat := t.fatten(bounds, d)
+	m := geom.Vector{X: 4 * t.margin, Y: 4 * t.margin}
+	huge := geom.AABB{Min: fat.Min.Add(m.Neg()), Max: fat.Max.Add(m)}
+	if old := t.nodes[leaf].bounds; encloses(old, bounds) && encloses(huge, old) {
+		return
+	}
+	t.removeLeaf(leaf)
+	t.nodes[leaf].bounds = fat
+	t.insertLeaf(leaf)
+}
+
+// Remove removes the item with handle id from the index.
+func (t *Tree[T]) Remove(id Handle) {
+	leaf := t.get(id).x
+	t.removeLeaf(leaf)
+	t.release(leaf)
+	t.remove(id)
+}
+
+func (t *Tree[T]) insertLeaf(leaf int) {
+	if t.root < 0 {
+		t.root = leaf
+		t.nodes[leaf].parent = -1
+		return
+	}
+
+	// Find the best sibling for the leaf, by the surface area heuristic
+	// (which, in 2D, concerns perimeters).
+	lb := t.nodes[leaf].bounds
+	i := t.root
+	for !t.nodes[i].leaf() {
+		n := &t.nodes[i]
+		p := perimeter(n.bounds)
+		combined := perimeter(n.bounds.Union(lb))
+
+		// The cost of making the leaf a sibling of this node, and the
+		// minimum cost of pushing it further down.
+		cost := 2 * combined
+		inherited := 2 * (combined - p)
+		descend := func(c int) float64 {
+			cb := t.nodes[c].bounds
+			u := perimeter(cb.Union(lb))
+			if t.nodes[c].leaf() {
+				return u + inherited
+			}
+			return u - perimeter(cb) + inherited
+		}
+		c0, c1 := descend(n.left), descend(n.right)
+		if cost < c0 && cost < c1 {
+			break
+		}
+		if c0 < c1 {
+			i = n.left
+		} else {
+			i = n.right
+		}
+	}
+
+	// Replace the sibling with a new parent for both.
+	sibling := i
+	oldParent := t.nodes[sibling].parent
+	parent := t.alloc()
+	t.nodes[parent] = tnode{
+		bounds: lb.Union(t.nodes[sibling].bounds),
+		parent: oldParent,
+		left:   sibling,
+		right:  leaf,
+		height: t.nodes[sibling].height + 1,
+	}
+	t.replaceChild(oldParent, sibling, parent)
+	t.nodes[sibling].parent = parent
+	t.nodes[leaf].parent = parent
+	t.refit(parent)
+}
+
+func (t *Tree[T]) removeLeaf(leaf int) {
+	if leaf == t.root {
+		t.root = -1
+		return
+	}
+	parent := t.nodes[leaf].parent
+	grandparent := t.nodes[parent].parent
+	sibling := t.nodes[parent].left
+	if sibling == leaf {
+		sibling = t.nodes[parent].right
+	}
+	t.replaceChild(grandparent, parent, sibling)
+	t.nodes[sibling].parent = grandparent
+	t.release(parent)
+	if grandparent >= 0 {
+		t.refit(grandparent)
+	}
+}
+
+// replaceChild makes to a child of parent in place of from, or the root
+// if parent is -1.
+func (t *Tree[T]) replaceChild(parent, from, to int) {
+	switch {
+	case parent < 0:
+		t.root = to
+	case t.nodes[parent].left == from:
+		t.nodes[parent].left = to
+	default:
+		t.nodes[parent].right = to
+	}
+}
+
+// refit rebalances the tree, and recomputes bounds and heights, from
+// node i up to the root.
+func (t *Tree[T]) refit(i int) {
+	for i >= 0 {
+		i = t.balance(i)
+		n := &t.nodes[i]
+		l, r := &t.nodes[n.left], &t.nodes[n.right]
+		n.height = 1 + max(l.height, r.height)
+		n.bounds = l.bounds.Union(r.bounds)
+		i = n.parent
+	}
+}
+
+// balance rotates the subtree rooted at a if one of its children is more
+// than one level taller than the other, and returns the subtree's new
+// root.
+func (t *Tree[T]) balance(a int) int {
+	na := &t.nodes[a]
+	if na.leaf() || na.height < 2 {
+		return a
+	}
+	b, c := na.left, na.right
+	switch d := t.nodes[c].height - t.nodes[b].height; {
+	case d > 1:
+		return t.rotate(a, c, b, false)
+	case d < -1:
+		return t.rotate(a, b, c, true)
+	}
+	return a
+}
+
+// rotate lifts up, the taller child of a, into a's place, and makes a a
+// child of up. other is a's other child, and left reports whether up is
+// a's left child.
+func (t *Tree[T]) rotate(a, up, other int, left bool) int {
+	nu := &t.nodes[up]
+	f, g := nu.left, nu.right
+	nu.left = a
+	nu.parent = t.nodes[a].parent
+	t.nodes[a].parent = up
+	t.replaceChild(nu.parent, a, up)
+
+	// Keep the taller of up's children, and give the shorter to a in
+	// up's place.
+	keep, give := f, g
+	if t.nodes[f].height <= t.nodes[g].height {
+		keep, give = g, f
+	}
+	nu.right = keep
+	na := &t.nodes[a]
+	if left {
+		na.left = give
+	} else {
+		na.right = give
+	}
+	t.nodes[give].parent = a
+	na.bounds = t.nodes[other].bounds.Union(t.nodes[give].bounds)
+	na.height = 1 + max(t.nodes[other].height, t.nodes[give].height)
+	nu.bounds = na.bounds.Union(t.nodes[keep].bounds)
+	nu.height = 1 + max(na.height, t.nodes[keep].height)
+	return up
+}
+
+// Query returns an iterator over the items whose bounds overlap region.
+func (t *Tree[T]) Query(region geom.AABB) iter.Seq2[Handle, T] {
+	return func(yield func(Handle, T) bool) {
+		t.search(func(b geom.AABB) bool { return b.Intersects(region) }, yield)
+	}
+}
+
+// QueryPoint returns an iterator over the items whose bounds contain p.
+func (t *Tree[T]) QueryPoint(p geom.Point) iter.Seq2[Handle, T] {
+	return func(yield func(Handle, T) bool) {
+		t.search(func(b geom.AABB) bool { return b.Contains(p) }, yield)
+	}
+}
+
+// search yields the items whose bounds match, by descending into each
+// node whose bounds match.
+func (t *Tree[T]) search(match func(geom.AABB) bool, yield func(Handle, T) bool) {
+	if t.root < 0 {
+		return
+	}
+	stack := []int{t.root}
+	for len(stack) > 0 {
+		n := &t.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if !match(n.bounds) {
+			continue
+		}
+		if !n.leaf() {
+			stack = append(stack, n.left, n.right)
+			continue
+		}
+		it := &t.items[n.item]
+		if match(it.bounds) && !yield(n.item, it.value) {
+			return
+		}
+	}
+}
+
+// Raycast returns an iterator over the items whose bounds are hit by r,
+// along with where each is hit, nearest first.
+//
+// Nodes are searched in the order the ray enters them, so little of the
+// tree is searched if iteration stops after the first few hits.
+func (t *Tree[T]) Raycast(r geom.Ray) iter.Seq2[Handle, geom.Hit] {
+	return func(yield func(Handle, geom.Hit) bool) {
+		if t.root < 0 {
+			return
+		}
+		var q rayQueue
+		if hit, ok := r.CastAABB(t.nodes[t.root].bounds); ok {
+			q.pushNode(t.root, hit.Dist)
+		}
+		for q.Len() > 0 {
+			e := q.pop()
+			if e.node < 0 {
+				if !yield(e.h, e.hit) {
+					return
+				}
+				continue
+			}
+			n := &t.nodes[e.node]
+			if n.leaf() {
+				if hit, ok := r.CastAABB(t.items[n.item].bounds); ok {
+					q.pushHit(n.item, hit)
+				}
+				continue
+			}
+			for _, c := range [2]int{n.left, n.right} {
+				if hit, ok := r.CastAABB(t.nodes[c].bounds); ok {
+					q.pushNode(c, hit.Dist)
+				}
+			}
+		}
+	}
+}
+
+// Pairs returns an iterator over every pair of items whose bounds
+// overlap.
+//
+// It searches the tree against itself, so only subtrees whose bounds
+// overlap are ever compared.
+func (t *Tree[T]) Pairs() iter.Seq2[Handle, Handle] {
+	return func(yield func(Handle, Handle) bool) {
+		if t.root >= 0 {
+			t.pairsWithin(t.root, yield)
+		}
+	}
+}
+
+// pairsWithin yields the overlapping pairs of items in subtree i.
+func (t *Tree[T]) pairsWithin(i int, yield func(Handle, Handle) bool) bool {
+	n := &t.nodes[i]
+	if n.leaf() {
+		return true
+	}
+	return t.pairsBetween(n.left, n.right, yield) &&
+		t.pairsWithin(n.left, yield) &&
+		t.pairsWithin(n.right, yield)
+}
+
+// pairsBetween yields the overlapping pairs of an item in subtree i with
+// an item in subtree j.
+func (t *Tree[T]) pairsBetween(i, j int, yield func(Handle, Handle) bool) bool {
+	ni, nj := &t.nodes[i], &t.nodes[j]
+	if !ni.bounds.Intersects(nj.bounds) {
+		return true
+	}
+	switch {
+	case ni.leaf() && nj.leaf():
+		if !t.items[ni.item].bounds.Intersects(t.items[nj.item].bounds) {
+			return true
+		}
+		return yield(ni.item, nj.item)
+	case nj.leaf() || (!ni.leaf() && perimeter(ni.bounds) > perimeter(nj.bounds)):
+		// Descend into the larger subtree.
+		return t.pairsBetween(ni.left, j, yield) && t.pairsBetween(ni.right, j, yield)
+	}
+	return t.pairsBetween(i, nj.left, yield) && t.pairsBetween(i, nj.right, yield)
+}
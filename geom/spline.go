@@ -0,0 +1,327 @@
+package geom
+
+import (
+	"iter"
+	"math"
+)
+
+// CatmullRom is a Catmull-Rom spline, which passes through each of
+// its points in order.
+//
+// Alpha controls how the spline is parameterized between points:
+// 0 is uniform, 0.5 is centripetal, and 1 is chordal. Centripetal
+// splines never form cusps or loops within a span between two points,
+// which makes them a good default for paths through waypoints.
+//
+// If Closed is true, the spline loops from the last point back to the first.
+//
+// CatmullRom implements Curve. Each span between adjacent points covers
+// an equal range of t.
+type CatmullRom struct {
+	Points []Point
+	Alpha  float64
+	Closed bool
+}
+
+// At implements Curve.
+func (cr CatmullRom) At(t float64) Point {
+	return splineAt(cr, cr.Points, t)
+}
+
+// Derivative returns the derivative of the spline with respect to t.
+func (cr CatmullRom) Derivative(t float64) Vector {
+	return splineDerivative(cr, t)
+}
+
+// Bounds returns the smallest AABB containing the spline.
+func (cr CatmullRom) Bounds() AABB {
+	return splineBounds(cr, cr.Points)
+}
+
+// Flatten is like [Flatten], but flattens each span of the spline
+// separately.
+func (cr CatmullRom) Flatten(tolerance float64) iter.Seq[Point] {
+	return splineFlatten(cr, cr.Points, tolerance)
+}
+
+// Beziers returns an iterator over cubic Bézier curves that exactly
+// describe each span of the spline, in order.
+func (cr CatmullRom) Beziers() iter.Seq[CubicBezier] {
+	return splineBeziers(cr)
+}
+
+func (cr CatmullRom) spans() int {
+	return closedSpans(len(cr.Points), cr.Closed)
+}
+
+func (cr CatmullRom) span(i int) CubicBezier {
+	p0, p1, p2, p3 := neighbors(cr.Points, i, cr.Closed)
+
+	// Convert the span to Hermite form, with tangents computed from
+	// non-uniform knot intervals. See Yuksel et al., "Parameterization
+	// and Applications of Catmull-Rom Curves".
+	d0 := math.Pow(Vec(p0, p1).Length(), cr.Alpha)
+	d1 := math.Pow(Vec(p1, p2).Length(), cr.Alpha)
+	d2 := math.Pow(Vec(p2, p3).Length(), cr.Alpha)
+	if d1 == 0 {
+		return Bezier3(p1, p1, p2, p2)
+	}
+	if d0 == 0 {
+		d0 = d1
+	}
+	if d2 == 0 {
+		d2 = d1
+	}
+	m1 := Vec(p0, p1).Scale(1 / d0).Sub(Vec(p0, p2).Scale(1 / (d0 + d1))).Add(Vec(p1, p2).Scale(1 / d1)).Scale(d1)
+	m2 := Vec(p1, p2).Scale(1 / d1).Sub(Vec(p1, p3).Scale(1 / (d1 + d2))).Add(Vec(p2, p3).Scale(1 / d2)).Scale(d1)
+	return hermite(p1, m1, p2, m2)
+}
+
+// Hermite is a cubic Hermite spline, which passes through each of its
+// points in order with the corresponding tangent from Tangents.
+//
+// Tangents must be the same length as Points. Each tangent is the
+// derivative of the spline at its point with respect to the parameter of
+// one span, so longer tangents pull the spline further in their direction.
+// Uniform tangent lengths yield a smooth [Hermite.Derivative].
+//
+// If Closed is true, the spline loops from the last point back to the first.
+//
+// Hermite implements Curve. Each span between adjacent points covers
+// an equal range of t.
+type Hermite struct {
+	Points   []Point
+	Tangents []Vector
+	Closed   bool
+}
+
+// At implements Curve.
+func (h Hermite) At(t float64) Point {
+	return splineAt(h, h.Points, t)
+}
+
+// Derivative returns the derivative of the spline with respect to t.
+func (h Hermite) Derivative(t float64) Vector {
+	return splineDerivative(h, t)
+}
+
+// Bounds returns the smallest AABB containing the spline.
+func (h Hermite) Bounds() AABB {
+	return splineBounds(h, h.Points)
+}
+
+// Flatten is like [Flatten], but flattens each span of the spline
+// separately.
+func (h Hermite) Flatten(tolerance float64) iter.Seq[Point] {
+	return splineFlatten(h, h.Points, tolerance)
+}
+
+// Beziers returns an iterator over cubic Bézier curves that exactly
+// describe each span of the spline, in order.
+func (h Hermite) Beziers() iter.Seq[CubicBezier] {
+	return splineBeziers(h)
+}
+
+func (h Hermite) spans() int {
+	return closedSpans(len(h.Points), h.Closed)
+}
+
+func (h Hermite) span(i int) CubicBezier {
+	j := (i + 1) % len(h.Points)
+	return hermite(h.Points[i], h.Tangents[i], h.Points[j], h.Tangents[j])
+}
+
+// hermite converts a cubic Hermite curve into Bézier form.
+func hermite(p0 Point, m0 Vector, p1 Point, m1 Vector) CubicBezier {
+	return Bezier3(p0, p0.Add(m0.Scale(1.0/3)), p1.Add(m1.Scale(-1.0/3)), p1)
+}
+
+// BSpline is a uniform cubic B-spline, which is pulled towards each of its
+// points in order but generally doesn't pass through them.
+//
+// If Clamped is true, the spline begins at the first point and ends at the
+// last, heading towards the second and second-to-last points respectively.
+// Otherwise, it begins and ends near the second and second-to-last points,
+// and at least four points are needed to describe any curve at all.
+//
+// If Closed is true, the spline loops smoothly through all the points and
+// Clamped is ignored.
+//
+// BSpline implements Curve. Each span of the spline covers an equal
+// range of t.
+type BSpline struct {
+	Points  []Point
+	Clamped bool
+	Closed  bool
+}
+
+// At implements Curve.
+func (bs BSpline) At(t float64) Point {
+	return splineAt(bs, bs.Points, t)
+}
+
+// Derivative returns the derivative of the spline with respect to t.
+func (bs BSpline) Derivative(t float64) Vector {
+	return splineDerivative(bs, t)
+}
+
+// Bounds returns the smallest AABB containing the spline.
+func (bs BSpline) Bounds() AABB {
+	return splineBounds(bs, bs.Points)
+}
+
+// Flatten is like [Flatten], but flattens each span of the spline
+// separately.
+func (bs BSpline) Flatten(tolerance float64) iter.Seq[Point] {
+	return splineFlatten(bs, bs.Points, tolerance)
+}
+
+// Beziers returns an iterator over cubic Bézier curves that exactly
+// describe each span of the spline, in order.
+func (bs BSpline) Beziers() iter.Seq[CubicBezier] {
+	return splineBeziers(bs)
+}
+
+func (bs BSpline) spans() int {
+	n := len(bs.Points)
+	switch {
+	case bs.Closed:
+		return closedSpans(n, true)
+	case bs.Clamped:
+		// Clamping adds a phantom point at each end.
+		return max(n-1, 0)
+	}
+	return max(n-3, 0)
+}
+
+func (bs BSpline) span(i int) CubicBezier {
+	var p0, p1, p2, p3 Point
+	if bs.Closed || bs.Clamped {
+		p0, p1, p2, p3 = neighbors(bs.Points, i, bs.Closed)
+	} else {
+		p0, p1, p2, p3 = bs.Points[i], bs.Points[i+1], bs.Points[i+2], bs.Points[i+3]
+	}
+	// Convert the span from the B-spline basis to the Bézier basis.
+	lerp := func(a, b Point, t float64) Point {
+		return a.Add(Vec(a, b).Scale(t))
+	}
+	b1 := lerp(p1, p2, 1.0/3)
+	b2 := lerp(p1, p2, 2.0/3)
+	b0 := lerp(lerp(p0, p1, 2.0/3), b1, 0.5)
+	b3 := lerp(b2, lerp(p2, p3, 1.0/3), 0.5)
+	return Bezier3(b0, b1, b2, b3)
+}
+
+// spline is a curve made of cubic Bézier spans joined end to end,
+// each of which covers an equal range of t.
+type spline interface {
+	spans() int
+	span(i int) CubicBezier
+}
+
+// closedSpans returns the number of spans between n points, which
+// is one more if the spline loops back to the first point.
+func closedSpans(n int, closed bool) int {
+	if closed {
+		return n
+	}
+	return max(n-1, 0)
+}
+
+// neighbors returns the points around span i, which runs from point i
+// to point i+1. Open splines are extended at each end with a phantom
+// point that continues the first or last leg in a straight line.
+func neighbors(pts []Point, i int, closed bool) (p0, p1, p2, p3 Point) {
+	n := len(pts)
+	if closed {
+		return pts[(i+n-1)%n], pts[i], pts[(i+1)%n], pts[(i+2)%n]
+	}
+	p1, p2 = pts[i], pts[i+1]
+	if i > 0 {
+		p0 = pts[i-1]
+	} else {
+		p0 = p1.Add(Vec(p2, p1))
+	}
+	if i+2 < n {
+		p3 = pts[i+2]
+	} else {
+		p3 = p2.Add(Vec(p1, p2))
+	}
+	return
+}
+
+// locate returns the span of s containing t, and the parameter within
+// that span. t is clamped to the spline's ends.
+func locate(s spline, t float64) (CubicBezier, float64) {
+	n := s.spans()
+	i := min(max(int(math.Floor(t*float64(n))), 0), n-1)
+	return s.span(i), t*float64(n) - float64(i)
+}
+
+// splineAt returns the point on s at t. If s has no spans, it returns the
+// first of pts, or Origin if there are none.
+func splineAt(s spline, pts []Point, t float64) Point {
+	if s.spans() == 0 {
+		if len(pts) == 0 {
+			return Origin
+		}
+		return pts[0]
+	}
+	b, u := locate(s, t)
+	return b.At(u)
+}
+
+func splineDerivative(s spline, t float64) Vector {
+	if s.spans() == 0 {
+		return Zero
+	}
+	b, u := locate(s, t)
+	return b.Derivative(u).Scale(float64(s.spans()))
+}
+
+func splineBounds(s spline, pts []Point) AABB {
+	n := s.spans()
+	if n == 0 {
+		p := splineAt(s, pts, 0)
+		return AABB{p, p}
+	}
+	b := s.span(0).Bounds()
+	for i := 1; i < n; i++ {
+		b = b.Union(s.span(i).Bounds())
+	}
+	return b
+}
+
+func splineFlatten(s spline, pts []Point, tolerance float64) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		n := s.spans()
+		if n == 0 {
+			yield(splineAt(s, pts, 0))
+			return
+		}
+		for i := range n {
+			first := true
+			for p := range s.span(i).Flatten(tolerance) {
+				// Each span begins where the last one ended.
+				if first && i > 0 {
+					first = false
+					continue
+				}
+				first = false
+				if !yield(p) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func splineBeziers(s spline) iter.Seq[CubicBezier] {
+	return func(yield func(CubicBezier) bool) {
+		for i := range s.spans() {
+			if !yield(s.span(i)) {
+				return
+			}
+		}
+	}
+}
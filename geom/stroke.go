@@ -0,0 +1,219 @@
+package geom
+
+import "math"
+
+// LineCap describes the shape at the ends of an open stroke.
+//
+// Its values mirror those of the vector package in Ebiten.
+type LineCap int
+
+const (
+	// LineCapButt ends the stroke squarely at its end points.
+	LineCapButt LineCap = iota
+
+	// LineCapRound ends the stroke with a semicircle.
+	LineCapRound
+
+	// LineCapSquare ends the stroke squarely, half the stroke's
+	// width beyond its end points.
+	LineCapSquare
+)
+
+// LineJoin describes the shape of a stroke where two of its segments meet.
+//
+// Its values mirror those of the vector package in Ebiten.
+type LineJoin int
+
+const (
+	// LineJoinMiter extends the outer edges of the segments until they
+	// meet, unless that would exceed the miter limit.
+	LineJoinMiter LineJoin = iota
+
+	// LineJoinBevel cuts the corner off with a straight line.
+	LineJoinBevel
+
+	// LineJoinRound rounds the corner off with an arc.
+	LineJoinRound
+)
+
+// StrokeOptions describes the shape of a stroke.
+type StrokeOptions struct {
+	// Width is the width of the stroke.
+	Width float64
+
+	// LineCap is the shape at the ends of open strokes.
+	LineCap LineCap
+
+	// LineJoin is the shape where segments meet.
+	LineJoin LineJoin
+
+	// MiterLimit is the largest ratio of a miter's length to the stroke's
+	// width before a LineJoinMiter join is drawn as a bevel instead.
+	// As in Ebiten, the default (zero) value always draws bevels.
+	MiterLimit float64
+}
+
+// Stroke returns the outline of the polyline through pts when stroked
+// with opts. If closed is true, the polyline joins its last point back to
+// its first, and has no caps.
+//
+// Rounded caps and joins are approximated to within tolerance. See [Flatten].
+// The outline is the union of the stroke's pieces, in the form described
+// by [Combine].
+func Stroke(pts []Point, closed bool, opts StrokeOptions, tolerance float64) []Polygon {
+	return Combine(Union, strokePieces(pts, closed, opts, tolerance), nil, NonZero)
+}
+
+// Stroke returns the outline of the path when stroked with opts.
+// See [Stroke] for details.
+func (p *Path) Stroke(opts StrokeOptions, tolerance float64) []Polygon {
+	var pieces []Polygon
+	for line, closed := range p.Flatten(tolerance) {
+		pieces = append(pieces, strokePieces(line, closed, opts, tolerance)...)
+	}
+	return Combine(Union, pieces, nil, NonZero)
+}
+
+// Offset returns the polygon grown outward by d, or shrunk inward if d is
+// negative, with corners shaped by join. See [StrokeOptions] for the
+// meaning of miterLimit, and [Flatten] for tolerance.
+//
+// The polygon's interior is determined by the [NonZero] fill rule, and the
+// result is in the form described by [Combine]. Shrinking a polygon may
+// split it into pieces or make it disappear entirely.
+func (p Polygon) Offset(d float64, join LineJoin, miterLimit, tolerance float64) []Polygon {
+	region := Combine(Union, []Polygon{p}, nil, NonZero)
+	if d == 0 {
+		return region
+	}
+	opts := StrokeOptions{Width: 2 * math.Abs(d), LineJoin: join, MiterLimit: miterLimit}
+	var border []Polygon
+	for _, q := range region {
+		border = append(border, strokePieces(q, true, opts, tolerance)...)
+	}
+	op := Union
+	if d < 0 {
+		op = Difference
+	}
+	return Combine(op, region, border, NonZero)
+}
+
+// strokePieces returns counter-clockwise polygons whose union is the
+// stroke of the polyline.
+func strokePieces(pts []Point, closed bool, opts StrokeOptions, tolerance float64) []Polygon {
+	r := opts.Width / 2
+	if r <= 0 {
+		return nil
+	}
+	var line []Point
+	for _, p := range pts {
+		if len(line) == 0 || line[len(line)-1] != p {
+			line = append(line, p)
+		}
+	}
+	if closed && len(line) > 1 && line[0] == line[len(line)-1] {
+		line = line[:len(line)-1]
+	}
+	var pieces []Polygon
+	add := func(p Polygon) {
+		if p.Winding() == Clockwise {
+			p = p.Reverse()
+		}
+		pieces = append(pieces, p)
+	}
+	switch len(line) {
+	case 0:
+		return nil
+	case 1:
+		// A lone point only shows up through its caps.
+		switch opts.LineCap {
+		case LineCapRound:
+			add(circlePolygon(line[0], r, tolerance))
+		case LineCapSquare:
+			v := Vector{r, r}
+			add(AABB{line[0].Add(v.Neg()), line[0].Add(v)}.Polygon())
+		}
+		return pieces
+	}
+
+	segs := len(line) - 1
+	if closed {
+		segs = len(line)
+	}
+	seg := func(i int) Segment {
+		return Seg(line[i%len(line)], line[(i+1)%len(line)])
+	}
+	for i := range segs {
+		s := seg(i)
+		n := leftNormal(Vec(s.Start, s.End).Normalize()).Scale(r)
+		add(Poly(s.Start.Add(n.Neg()), s.End.Add(n.Neg()), s.End.Add(n), s.Start.Add(n)))
+	}
+	for i := range segs {
+		if i+1 == segs && !closed {
+			break
+		}
+		if p, ok := joinPolygon(seg(i), seg(i+1), opts, tolerance); ok {
+			add(p)
+		}
+	}
+	if !closed {
+		for _, end := range [2]Segment{Seg(line[1], line[0]), seg(segs - 1)} {
+			switch opts.LineCap {
+			case LineCapRound:
+				add(circlePolygon(end.End, r, tolerance))
+			case LineCapSquare:
+				u := Vec(end.Start, end.End).Normalize().Scale(r)
+				n := leftNormal(u)
+				e := end.End
+				add(Poly(e.Add(n.Neg()), e.Add(u).Add(n.Neg()), e.Add(u).Add(n), e.Add(n)))
+			}
+		}
+	}
+	return pieces
+}
+
+// joinPolygon returns the piece of a stroke that fills the gap on the outside
+// of the corner where s0 ends and s1 begins, if there is one.
+func joinPolygon(s0, s1 Segment, opts StrokeOptions, tolerance float64) (Polygon, bool) {
+	r := opts.Width / 2
+	p := s0.End
+	u0 := Vec(s0.Start, s0.End).Normalize()
+	u1 := Vec(s1.Start, s1.End).Normalize()
+	turn := crossMag(u0, u1)
+	if turn == 0 && u0.Dot(u1) > 0 {
+		return nil, false
+	}
+	if opts.LineJoin == LineJoinRound {
+		return circlePolygon(p, r, tolerance), true
+	}
+	// The gap is on the right of a left turn, and vice versa.
+	n0, n1 := leftNormal(u0).Scale(r), leftNormal(u1).Scale(r)
+	if turn > 0 {
+		n0, n1 = n0.Neg(), n1.Neg()
+	}
+	if opts.LineJoin == LineJoinMiter {
+		// The miter's length relative to the stroke's width is
+		// 1/sin(theta/2), where theta is the angle between the segments.
+		sinHalf := math.Sqrt(max(0, (1+u0.Dot(u1))/2))
+		if sinHalf > 0 && 1/sinHalf <= opts.MiterLimit {
+			m := p.Add(n0.Add(n1).Normalize().Scale(r / sinHalf))
+			return Poly(p, p.Add(n0), m, p.Add(n1)), true
+		}
+	}
+	return Poly(p, p.Add(n0), p.Add(n1)), true
+}
+
+// circlePolygon returns a polygon inscribed in the circle, whose edges
+// stray no more than tolerance from it.
+func circlePolygon(c Point, r, tolerance float64) Polygon {
+	n := 8
+	if tolerance > 0 && tolerance < r {
+		n = max(n, int(math.Ceil(math.Pi/math.Acos(1-tolerance/r))))
+	}
+	p := make(Polygon, n)
+	for i := range p {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		p[i] = c.Add(Vector{r * math.Cos(a), r * math.Sin(a)})
+	}
+	return p
+}
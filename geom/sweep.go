@@ -0,0 +1,87 @@
+package geom
+
+import "math"
+
+// Impact describes the first contact between a moving shape and
+// a stationary one.
+type Impact struct {
+	// Time is the fraction of the motion, over the closed interval
+	// [0, 1], at which the moving shape first makes contact.
+	Time float64
+
+	// Normal is the unit surface normal of the stationary shape at
+	// the point of contact, pointing toward the moving shape.
+	Normal Vector
+
+	// Remaining is the part of the motion left over after contact.
+	Remaining Vector
+}
+
+// Slide returns the remaining motion with the component into the
+// surface removed, so that the moving shape slides along it.
+func (i Impact) Slide() Vector {
+	return i.Remaining.Sub(i.Normal.Scale(i.Remaining.Dot(i.Normal)))
+}
+
+// Bounce returns the remaining motion reflected off of the surface.
+//
+// restitution scales the reflected component: 1 is a perfectly elastic
+// bounce, while 0 is equivalent to Slide.
+func (i Impact) Bounce(restitution float64) Vector {
+	return i.Remaining.Sub(i.Normal.Scale((1 + restitution) * i.Remaining.Dot(i.Normal)))
+}
+
+// Sweep moves a along v and returns the first Impact with b, if any.
+//
+// Returns false if a does not hit b over the course of the motion.
+// Also returns false if a and b already intersect; use [AABB.Penetration]
+// to resolve that case.
+func (a AABB) Sweep(v Vector, b AABB) (Impact, bool) {
+	return sweep(a.Polygon(), b.Polygon(), []Vector{{1, 0}, {0, 1}}, v)
+}
+
+// SweepSegment moves a along v and returns the first Impact with s, if any.
+//
+// Returns false if a does not hit s over the course of the motion.
+// Also returns false if a and s already intersect.
+func (a AABB) SweepSegment(v Vector, s Segment) (Impact, bool) {
+	axes := []Vector{{1, 0}, {0, 1}}
+	if !s.ZeroLength() {
+		axes = append(axes, Vec(s.Start, s.End).RightNormal().Normalize())
+	}
+	return sweep(a.Polygon(), Polygon{s.Start, s.End}, axes, v)
+}
+
+// sweep finds the time of impact between convex polygon a moving along v
+// and stationary convex polygon b by applying the separating axis test
+// over time to each of the provided unit axes.
+func sweep(a, b Polygon, axes []Vector, v Vector) (Impact, bool) {
+	enter, exit := math.Inf(-1), math.Inf(1)
+	var normal Vector
+	for _, axis := range axes {
+		aMin, aMax := a.project(axis)
+		bMin, bMax := b.project(axis)
+		speed := v.Dot(axis)
+		if speed == 0 {
+			if aMax <= bMin || aMin >= bMax {
+				return Impact{}, false
+			}
+			continue
+		}
+		t0 := (bMin - aMax) / speed
+		t1 := (bMax - aMin) / speed
+		n := axis.Neg()
+		if speed < 0 {
+			t0, t1 = t1, t0
+			n = axis
+		}
+		if t0 > enter {
+			enter, normal = t0, n
+		}
+		exit = min(exit, t1)
+	}
+	if enter < 0 || enter > 1 || enter >= exit {
+		return Impact{}, false
+	}
+	return Impact{Time: enter, Normal: normal, Remaining: v.Scale(1 - enter)}, true
+}
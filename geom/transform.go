@@ -0,0 +1,208 @@
+package geom
+
+import "math"
+
+// Transform is a 2D affine transformation, represented by the matrix
+//
+//	| a  b  tx |
+//	| c  d  ty |
+//
+// which maps a point (x, y) to (a*x + b*y + tx, c*x + d*y + ty).
+//
+// The zero value is the identity transformation. Methods that build on a
+// transformation, such as Translate and Rotate, return a new transformation
+// that applies the receiver first, mirroring the behavior of ebiten.GeoM.
+type Transform struct {
+	// a1 and d1 are stored less one, so that the zero value is the identity.
+	a1, b, c, d1, tx, ty float64
+}
+
+// Affine creates a new Transform from the elements of its matrix.
+func Affine(a, b, c, d, tx, ty float64) Transform {
+	return Transform{a - 1, b, c, d - 1, tx, ty}
+}
+
+// Elements returns the elements of the transformation's matrix.
+func (t Transform) Elements() (a, b, c, d, tx, ty float64) {
+	return t.a1 + 1, t.b, t.c, t.d1 + 1, t.tx, t.ty
+}
+
+// Then returns the transformation that applies t, followed by u.
+func (t Transform) Then(u Transform) Transform {
+	ta, tb, tc, td, ttx, tty := t.Elements()
+	ua, ub, uc, ud, utx, uty := u.Elements()
+	return Affine(
+		ua*ta+ub*tc, ua*tb+ub*td,
+		uc*ta+ud*tc, uc*tb+ud*td,
+		ua*ttx+ub*tty+utx, uc*ttx+ud*tty+uty,
+	)
+}
+
+// Translate returns t followed by a translation by v.
+func (t Transform) Translate(v Vector) Transform {
+	t.tx += v.X
+	t.ty += v.Y
+	return t
+}
+
+// Scale returns t followed by scaling by sx and sy about the origin.
+func (t Transform) Scale(sx, sy float64) Transform {
+	return t.Then(Affine(sx, 0, 0, sy, 0, 0))
+}
+
+// Rotate returns t followed by an anticlockwise rotation by rad radians
+// about the origin.
+func (t Transform) Rotate(rad float64) Transform {
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	return t.Then(Affine(cos, -sin, sin, cos, 0, 0))
+}
+
+// Skew returns t followed by shearing by the angles sx and sy, in radians,
+// about the origin.
+func (t Transform) Skew(sx, sy float64) Transform {
+	return t.Then(Affine(1, math.Tan(sx), math.Tan(sy), 1, 0, 0))
+}
+
+// ScaleAbout is like Scale, but scales about p.
+func (t Transform) ScaleAbout(sx, sy float64, p Point) Transform {
+	return t.Translate(Vec(p, Origin)).Scale(sx, sy).Translate(p.Vector())
+}
+
+// RotateAbout is like Rotate, but rotates about p.
+func (t Transform) RotateAbout(rad float64, p Point) Transform {
+	return t.Translate(Vec(p, Origin)).Rotate(rad).Translate(p.Vector())
+}
+
+// SkewAbout is like Skew, but shears about p.
+func (t Transform) SkewAbout(sx, sy float64, p Point) Transform {
+	return t.Translate(Vec(p, Origin)).Skew(sx, sy).Translate(p.Vector())
+}
+
+// Det returns the determinant of the transformation's linear part.
+//
+// Its magnitude is the factor by which the transformation scales areas,
+// and it is negative if the transformation reflects.
+func (t Transform) Det() float64 {
+	a, b, c, d, _, _ := t.Elements()
+	return a*d - b*c
+}
+
+// Invert returns the inverse of t. Returns false if t is not invertible.
+func (t Transform) Invert() (Transform, bool) {
+	det := t.Det()
+	if det == 0 {
+		return Transform{}, false
+	}
+	a, b, c, d, tx, ty := t.Elements()
+	return Affine(
+		d/det, -b/det,
+		-c/det, a/det,
+		(b*ty-d*tx)/det, (c*tx-a*ty)/det,
+	), true
+}
+
+// Decompose breaks t down into simpler transformations, such that t is
+// equivalent to
+//
+//	Transform{}.Scale(scale.X, scale.Y).Skew(skew, 0).Rotate(rotate).Translate(translate)
+//
+// Reflections are expressed as a negative scale.Y. If t collapses
+// everything onto a point, only translate is meaningful.
+func (t Transform) Decompose() (translate Vector, rotate float64, scale Vector, skew float64) {
+	a, b, c, d, tx, ty := t.Elements()
+	translate = Vector{tx, ty}
+	scale.X = math.Hypot(a, c)
+	if scale.X == 0 {
+		return
+	}
+	rotate = math.Atan2(c, a)
+	cos, sin := a/scale.X, c/scale.X
+	scale.Y = -sin*b + cos*d
+	if scale.Y != 0 {
+		skew = math.Atan((cos*b + sin*d) / scale.Y)
+	}
+	return
+}
+
+// Apply returns p transformed by t.
+func (t Transform) Apply(p Point) Point {
+	a, b, c, d, tx, ty := t.Elements()
+	return Pt(a*p.X+b*p.Y+tx, c*p.X+d*p.Y+ty)
+}
+
+// ApplyVector returns v transformed by t. Vectors are relative,
+// so they're unaffected by the translation in t.
+func (t Transform) ApplyVector(v Vector) Vector {
+	a, b, c, d, _, _ := t.Elements()
+	return Vector{a*v.X + b*v.Y, c*v.X + d*v.Y}
+}
+
+// ApplySegment returns s transformed by t.
+func (t Transform) ApplySegment(s Segment) Segment {
+	return Seg(t.Apply(s.Start), t.Apply(s.End))
+}
+
+// ApplyAABB returns the smallest AABB containing a transformed by t.
+func (t Transform) ApplyAABB(a AABB) AABB {
+	p := a.Polygon()
+	b := AABB{t.Apply(p[0]), t.Apply(p[0])}
+	for _, pt := range p[1:] {
+		b = b.extend(t.Apply(pt))
+	}
+	return b
+}
+
+// ApplyCurve returns c transformed by t.
+//
+// Segments, Bézier curves, Hermite splines, and B-splines are transformed
+// by transforming their points, so the result has the same type as c.
+// Other curves are wrapped in a Curve that transforms each point as it's
+// evaluated.
+func (t Transform) ApplyCurve(c Curve) Curve {
+	switch c := c.(type) {
+	case Segment:
+		return t.ApplySegment(c)
+	case QuadraticBezier:
+		return Bezier2(t.Apply(c.a), t.Apply(c.b), t.Apply(c.c))
+	case CubicBezier:
+		return Bezier3(t.Apply(c.a), t.Apply(c.b), t.Apply(c.c), t.Apply(c.d))
+	case Hermite:
+		h := Hermite{
+			Points:   t.applyPoints(c.Points),
+			Tangents: make([]Vector, len(c.Tangents)),
+			Closed:   c.Closed,
+		}
+		for i, v := range c.Tangents {
+			h.Tangents[i] = t.ApplyVector(v)
+		}
+		return h
+	case BSpline:
+		c.Points = t.applyPoints(c.Points)
+		return c
+	}
+	return transformedCurve{c, t}
+}
+
+func (t Transform) applyPoints(pts []Point) []Point {
+	q := make([]Point, len(pts))
+	for i, p := range pts {
+		q[i] = t.Apply(p)
+	}
+	return q
+}
+
+// transformedCurve is a Curve transformed by an arbitrary Transform.
+type transformedCurve struct {
+	c Curve
+	t Transform
+}
+
+// At implements Curve.
+func (tc transformedCurve) At(t float64) Point {
+	return tc.t.Apply(tc.c.At(t))
+}
+
+// Derivative returns the derivative of the curve with respect to t.
+func (tc transformedCurve) Derivative(t float64) Vector {
+	return tc.t.ApplyVector(derivative(tc.c, t))
+}
@@ -0,0 +1,252 @@
+package geom
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+// Triangulate splits a simple polygon with the provided holes into
+// triangles, using ear clipping.
+//
+// The result holds three indices for each triangle. Indices refer to the
+// vertices of outer, followed by the vertices of each hole in turn, as if
+// they were all appended into a single slice. Each triangle is
+// counter-clockwise. See [Winding].
+//
+// The polygon and holes may have either winding. Holes must lie within the
+// polygon and must not overlap each other or the polygon's boundary.
+// Triangulate returns an error, along with the triangles it managed to
+// produce, if the polygon or holes are not simple. Polygons and holes
+// with no area produce no triangles.
+//
+// Ear clipping takes quadratic time in the number of vertices, which is
+// fine for hand-authored shapes but slow for very large polygons.
+func Triangulate(outer Polygon, holes ...Polygon) ([]int, error) {
+	t := &triangulator{}
+	t.pts = append(t.pts, outer...)
+	start := t.ring(outer, 0, CounterClockwise)
+	if start < 0 {
+		return nil, nil
+	}
+	var rings []int
+	base := len(outer)
+	for _, h := range holes {
+		t.pts = append(t.pts, h...)
+		if r := t.ring(h, base, Clockwise); r >= 0 {
+			rings = append(rings, r)
+		}
+		base += len(h)
+	}
+
+	// Bridge holes into the outer ring from right to left, so that each
+	// bridge only has to avoid holes that have already been merged.
+	for i, r := range rings {
+		rings[i] = t.rightmost(r)
+	}
+	slices.SortFunc(rings, func(a, b int) int {
+		return cmp.Compare(t.pt(b).X, t.pt(a).X)
+	})
+	for i, m := range rings {
+		v := t.bridge(start, m, rings[i+1:])
+		if v < 0 {
+			return nil, errNotSimple
+		}
+		t.split(v, m)
+	}
+	return t.clip(start)
+}
+
+var errNotSimple = errors.New("geom: cannot triangulate polygon that is not simple")
+
+// triangulator holds the state for Triangulate: a set of rings of
+// vertices stored as doubly-linked lists of nodes.
+type triangulator struct {
+	pts []Point
+
+	// idx, prev, and next hold the vertex index and neighbors of each node.
+	idx, prev, next []int
+}
+
+func (t *triangulator) pt(n int) Point {
+	return t.pts[t.idx[n]]
+}
+
+// node adds a node for vertex i, following node after or in a new ring
+// if after is negative.
+func (t *triangulator) node(i, after int) int {
+	n := len(t.idx)
+	t.idx = append(t.idx, i)
+	if after < 0 {
+		t.prev = append(t.prev, n)
+		t.next = append(t.next, n)
+		return n
+	}
+	t.prev = append(t.prev, after)
+	t.next = append(t.next, t.next[after])
+	t.prev[t.next[after]] = n
+	t.next[after] = n
+	return n
+}
+
+func (t *triangulator) remove(n int) {
+	t.next[t.prev[n]] = t.next[n]
+	t.prev[t.next[n]] = t.prev[n]
+}
+
+// ring adds a ring for the polygon p, whose vertices start at index base,
+// in the order given by w. Repeated vertices are dropped. Returns a node
+// in the ring, or -1 if the polygon has no area.
+func (t *triangulator) ring(p Polygon, base int, w Winding) int {
+	pw := p.Winding()
+	if pw == Degenerate {
+		return -1
+	}
+	last := -1
+	for j := range p {
+		i := j
+		if pw != w {
+			i = len(p) - 1 - j
+		}
+		if last >= 0 && t.pt(last) == p[i] {
+			continue
+		}
+		last = t.node(base+i, last)
+	}
+	if t.pt(last) == t.pt(t.next[last]) {
+		t.remove(last)
+		last = t.next[last]
+	}
+	return last
+}
+
+// rightmost returns the node in the ring with the largest X coordinate.
+func (t *triangulator) rightmost(r int) int {
+	best := r
+	for n := t.next[r]; n != r; n = t.next[n] {
+		if p, b := t.pt(n), t.pt(best); p.X > b.X || (p.X == b.X && p.Y < b.Y) {
+			best = n
+		}
+	}
+	return best
+}
+
+// bridge returns the nearest node in the outer ring that hole node m
+// can be connected to without crossing any edge, or -1 if there is none.
+func (t *triangulator) bridge(outer, m int, unmerged []int) int {
+	mp := t.pt(m)
+	best, bestD := -1, 0.0
+	n := outer
+	for {
+		if t.locallyInside(n, mp) {
+			s := Seg(t.pt(n), mp)
+			d := Vec(s.Start, s.End).Length2()
+			if (best < 0 || d < bestD) && !t.crosses(outer, s) && !t.crosses(m, s) && !slices.ContainsFunc(unmerged, func(r int) bool {
+				return t.crosses(r, s)
+			}) {
+				best, bestD = n, d
+			}
+		}
+		if n = t.next[n]; n == outer {
+			return best
+		}
+	}
+}
+
+// crosses returns true if s crosses any edge of the ring containing r,
+// other than by touching at one of its end points.
+func (t *triangulator) crosses(r int, s Segment) bool {
+	n := r
+	for {
+		if x, ok := Seg(t.pt(n), t.pt(t.next[n])).Intersection(s); ok {
+			if !x.ZeroLength() || (x.Start != s.Start && x.Start != s.End) {
+				return true
+			}
+		}
+		if n = t.next[n]; n == r {
+			return false
+		}
+	}
+}
+
+// locallyInside returns true if a diagonal from node n towards p begins
+// inside the ring, between n's neighbors.
+func (t *triangulator) locallyInside(n int, p Point) bool {
+	a, b, c := t.pt(t.prev[n]), t.pt(n), t.pt(t.next[n])
+	if orient(a, b, c) >= 0 {
+		return orient(b, p, c) <= 0 && orient(b, a, p) <= 0
+	}
+	return orient(b, p, a) > 0 || orient(b, c, p) > 0
+}
+
+// split joins the ring containing a to the ring containing b by a pair of
+// coincident edges between them, duplicating a and b.
+func (t *triangulator) split(a, b int) {
+	an, bp := t.next[a], t.prev[b]
+	a2 := t.node(t.idx[a], -1)
+	b2 := t.node(t.idx[b], -1)
+	t.next[a], t.prev[b] = b, a
+	t.next[a2], t.prev[an] = an, a2
+	t.next[b2], t.prev[a2] = a2, b2
+	t.next[bp], t.prev[b2] = b2, bp
+}
+
+// clip clips ears from the ring containing start until one triangle is left.
+func (t *triangulator) clip(start int) ([]int, error) {
+	var tris []int
+	count := 1
+	for n := t.next[start]; n != start; n = t.next[n] {
+		count++
+	}
+	n, stop := start, start
+	for count > 3 {
+		p, nx := t.prev[n], t.next[n]
+		if t.ear(n) {
+			tris = append(tris, t.idx[p], t.idx[n], t.idx[nx])
+			t.remove(n)
+			count--
+			n, stop = nx, nx
+			continue
+		}
+		if n = nx; n != stop {
+			continue
+		}
+		// No ears left. Drop a vertex that lies on a straight line,
+		// if there is one, since it contributes no area.
+		for {
+			if orient(t.pt(t.prev[n]), t.pt(n), t.pt(t.next[n])) == 0 {
+				break
+			}
+			if n = t.next[n]; n == stop {
+				return tris, errNotSimple
+			}
+		}
+		nx = t.next[n]
+		t.remove(n)
+		count--
+		n, stop = nx, nx
+	}
+	if p, nx := t.prev[n], t.next[n]; orient(t.pt(p), t.pt(n), t.pt(nx)) > 0 {
+		tris = append(tris, t.idx[p], t.idx[n], t.idx[nx])
+	}
+	return tris, nil
+}
+
+// ear returns true if the triangle formed by node n and its neighbors
+// lies within the ring and contains no other vertices of it.
+func (t *triangulator) ear(n int) bool {
+	a, b, c := t.pt(t.prev[n]), t.pt(n), t.pt(t.next[n])
+	if orient(a, b, c) <= 0 {
+		return false
+	}
+	for m := t.next[t.next[n]]; m != t.prev[n]; m = t.next[m] {
+		p := t.pt(m)
+		if p == a || p == b || p == c {
+			continue
+		}
+		if orient(a, b, p) >= 0 && orient(b, c, p) >= 0 && orient(c, a, p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
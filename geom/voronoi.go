@@ -0,0 +1,105 @@
+package geom
+
+import (
+	"iter"
+	"slices"
+)
+
+// Voronoi is a Voronoi diagram of a set of points, clipped to an AABB.
+// It divides the AABB into cells, one for each point, where every
+// location in a cell is closer to that cell's point than to any other.
+type Voronoi struct {
+	// Bounds is the AABB that the diagram is clipped to.
+	Bounds AABB
+
+	// Cells holds the cell for each point. Cells are convex and
+	// counter-clockwise. See [Winding]. The cells of points that lie
+	// outside Bounds may be empty, as are the cells of later copies of
+	// duplicate points.
+	Cells []Polygon
+
+	// nbrs holds the cells that share an edge with each cell.
+	nbrs [][]int
+}
+
+// NewVoronoi computes the Voronoi diagram of pts, clipped to bounds.
+func NewVoronoi(pts []Point, bounds AABB) *Voronoi {
+	return NewDelaunay(pts).Voronoi(bounds)
+}
+
+// Voronoi computes the Voronoi diagram of the triangulated points,
+// clipped to bounds. It's the dual of the triangulation: the vertices of
+// each cell are the circumcenters of the triangles around its point.
+func (d *Delaunay) Voronoi(bounds AABB) *Voronoi {
+	v := &Voronoi{
+		Bounds: bounds,
+		Cells:  make([]Polygon, len(d.Points)),
+		nbrs:   make([][]int, len(d.Points)),
+	}
+	// Edges shorter than this are treated as single points, which
+	// happens when four or more points lie on the same circle.
+	eps := 1e-9 * max(bounds.Dx(), bounds.Dy())
+	for i, p := range d.Points {
+		if len(d.nbrs[i]) == 0 && slices.Index(d.Points, p) != i {
+			continue
+		}
+		// The cell is the part of bounds on p's side of the perpendicular
+		// bisector between p and each of its neighbors. Track which
+		// neighbor produced each edge, with -1 for edges of bounds.
+		cell := bounds.Polygon()
+		edges := []int{-1, -1, -1, -1}
+		for j := range d.Neighbors(i) {
+			cell, edges = clipCell(cell, edges, p, d.Points[j], j)
+		}
+		v.Cells[i] = cell
+		for k, j := range edges {
+			if j >= 0 && Vec(cell[k], cell[(k+1)%len(cell)]).Length() > eps {
+				v.nbrs[i] = append(v.nbrs[i], j)
+				v.nbrs[j] = append(v.nbrs[j], i)
+			}
+		}
+	}
+	for i, n := range v.nbrs {
+		slices.Sort(n)
+		v.nbrs[i] = slices.Compact(n)
+	}
+	return v
+}
+
+// clipCell clips the convex polygon cell to the half-plane of points closer
+// to p than q. edges holds a label for each edge of cell, and the label of
+// the new edge along the boundary of the half-plane is label.
+func clipCell(cell Polygon, edges []int, p, q Point, label int) (Polygon, []int) {
+	mid := Seg(p, q).At(0.5)
+	n := Vec(p, q)
+	var out Polygon
+	var outEdges []int
+	for k, cur := range cell {
+		nxt := cell[(k+1)%len(cell)]
+		dc, dn := Vec(mid, cur).Dot(n), Vec(mid, nxt).Dot(n)
+		if dc <= 0 {
+			out = append(out, cur)
+			if dc == 0 && dn > 0 {
+				// The edge leaves along the half-plane's boundary.
+				outEdges = append(outEdges, label)
+			} else {
+				outEdges = append(outEdges, edges[k])
+			}
+		}
+		if (dc < 0 && dn > 0) || (dc > 0 && dn < 0) {
+			out = append(out, Seg(cur, nxt).At(dc/(dc-dn)))
+			if dc < 0 {
+				outEdges = append(outEdges, label)
+			} else {
+				outEdges = append(outEdges, edges[k])
+			}
+		}
+	}
+	return out, outEdges
+}
+
+// Neighbors returns an iterator over the points whose cells share an
+// edge with the cell of point i, in ascending order.
+func (v *Voronoi) Neighbors(i int) iter.Seq[int] {
+	return slices.Values(v.nbrs[i])
+}